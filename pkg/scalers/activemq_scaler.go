@@ -9,7 +9,6 @@ import (
 	"html/template"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -27,18 +26,45 @@ type activeMQScaler struct {
 	logger     logr.Logger
 }
 
+//revive:disable:var-naming breaking change on restAPITemplate, wouldn't bring any benefit to users
 type activeMQMetadata struct {
-	managementEndpoint        string
-	destinationName           string
-	brokerName                string
-	username                  string
-	password                  string
-	restAPITemplate           string
-	targetQueueSize           int64
-	activationTargetQueueSize int64
-	corsHeader                string
-	metricName                string
-	triggerIndex              int
+	// the endpoint, destination and broker fields are only required without restAPITemplate,
+	// otherwise they are parsed from the template
+	ManagementEndpoint        string `keda:"name=managementEndpoint,        order=triggerMetadata, optional"`
+	DestinationName           string `keda:"name=destinationName,           order=triggerMetadata, optional"`
+	BrokerName                string `keda:"name=brokerName,                order=triggerMetadata, optional"`
+	Username                  string `keda:"name=username,                  order=authParams;resolvedEnv;triggerMetadata, optional"`
+	Password                  string `keda:"name=password,                  order=authParams;resolvedEnv;triggerMetadata, optional"`
+	RestAPITemplate           string `keda:"name=restAPITemplate,           order=triggerMetadata, optional"`
+	TargetQueueSize           int64  `keda:"name=targetQueueSize,           order=triggerMetadata, default=10"`
+	ActivationTargetQueueSize int64  `keda:"name=activationTargetQueueSize, order=triggerMetadata, default=0"`
+	CorsHeader                string `keda:"name=corsHeader,                order=triggerMetadata, optional"`
+
+	metricName   string
+	triggerIndex int
+}
+
+//revive:enable:var-naming
+
+func (m *activeMQMetadata) Validate() error {
+	if m.RestAPITemplate == "" {
+		if m.ManagementEndpoint == "" {
+			return errors.New("no management endpoint given")
+		}
+		if m.DestinationName == "" {
+			return errors.New("no destination name given")
+		}
+		if m.BrokerName == "" {
+			return errors.New("no broker name given")
+		}
+	}
+	if m.Username == "" {
+		return errors.New("username cannot be empty")
+	}
+	if m.Password == "" {
+		return errors.New("password cannot be empty")
+	}
+	return nil
 }
 
 type activeMQMonitoring struct {
@@ -48,9 +74,8 @@ type activeMQMonitoring struct {
 }
 
 const (
-	defaultTargetQueueSize           = 10
-	defaultActivationTargetQueueSize = 0
-	defaultActiveMQRestAPITemplate   = "http://{{.ManagementEndpoint}}/api/jolokia/read/org.apache.activemq:type=Broker,brokerName={{.BrokerName}},destinationType=Queue,destinationName={{.DestinationName}}/QueueSize"
+	defaultTargetQueueSize         = 10
+	defaultActiveMQRestAPITemplate = "http://{{.ManagementEndpoint}}/api/jolokia/read/org.apache.activemq:type=Broker,brokerName={{.BrokerName}},destinationType=Queue,destinationName={{.DestinationName}}/QueueSize"
 )
 
 // NewActiveMQScaler creates a new activeMQ Scaler
@@ -75,106 +100,59 @@ func NewActiveMQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 }
 
 func parseActiveMQMetadata(config *scalersconfig.ScalerConfig) (*activeMQMetadata, error) {
-	meta := activeMQMetadata{}
+	meta := activeMQMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, err
+	}
 
-	if val, ok := config.TriggerMetadata["restAPITemplate"]; ok && val != "" {
-		meta.restAPITemplate = config.TriggerMetadata["restAPITemplate"]
+	if meta.RestAPITemplate != "" {
+		// the template takes precedence over the individual endpoint, destination and broker fields
 		var err error
 		if meta, err = getRestAPIParameters(meta); err != nil {
-			return nil, fmt.Errorf("can't parse restAPITemplate : %s ", err)
-		}
-	} else {
-		meta.restAPITemplate = defaultActiveMQRestAPITemplate
-		if config.TriggerMetadata["managementEndpoint"] == "" {
-			return nil, errors.New("no management endpoint given")
-		}
-		meta.managementEndpoint = config.TriggerMetadata["managementEndpoint"]
-
-		if config.TriggerMetadata["destinationName"] == "" {
-			return nil, errors.New("no destination name given")
-		}
-		meta.destinationName = config.TriggerMetadata["destinationName"]
-
-		if config.TriggerMetadata["brokerName"] == "" {
-			return nil, errors.New("no broker name given")
-		}
-		meta.brokerName = config.TriggerMetadata["brokerName"]
-	}
-
-	if val, ok := config.TriggerMetadata["targetQueueSize"]; ok {
-		queueSize, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("invalid targetQueueSize - must be an integer")
+			return nil, fmt.Errorf("can't parse restAPITemplate : %w", err)
 		}
-
-		meta.targetQueueSize = queueSize
 	} else {
-		meta.targetQueueSize = defaultTargetQueueSize
+		meta.RestAPITemplate = defaultActiveMQRestAPITemplate
 	}
 
-	if val, ok := config.TriggerMetadata["activationTargetQueueSize"]; ok {
-		activationTargetQueueSize, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("invalid activationTargetQueueSize - must be an integer")
-		}
-		meta.activationTargetQueueSize = activationTargetQueueSize
-	} else {
-		meta.activationTargetQueueSize = defaultActivationTargetQueueSize
+	if meta.CorsHeader == "" {
+		meta.CorsHeader = fmt.Sprintf(defaultCorsHeader, meta.ManagementEndpoint)
 	}
 
-	if val, ok := config.AuthParams["username"]; ok && val != "" {
-		meta.username = val
-	} else if val, ok := config.TriggerMetadata["username"]; ok && val != "" {
-		username := val
-
-		if val, ok := config.ResolvedEnv[username]; ok && val != "" {
-			meta.username = val
-		} else {
-			meta.username = username
+	// for backwards compatibility, username and password from trigger metadata may also
+	// name an environment variable of the scale target holding the value
+	if meta.Username == config.TriggerMetadata["username"] {
+		if val, ok := config.ResolvedEnv[meta.Username]; ok && val != "" {
+			meta.Username = val
 		}
 	}
-
-	if val, ok := config.TriggerMetadata["corsHeader"]; ok && val != "" {
-		meta.corsHeader = config.TriggerMetadata["corsHeader"]
-	} else {
-		meta.corsHeader = fmt.Sprintf(defaultCorsHeader, meta.managementEndpoint)
-	}
-
-	if meta.username == "" {
-		return nil, fmt.Errorf("username cannot be empty")
-	}
-
-	if val, ok := config.AuthParams["password"]; ok && val != "" {
-		meta.password = val
-	} else if val, ok := config.TriggerMetadata["password"]; ok && val != "" {
-		password := val
-
-		if val, ok := config.ResolvedEnv[password]; ok && val != "" {
-			meta.password = val
-		} else {
-			meta.password = password
+	if meta.Password == config.TriggerMetadata["password"] {
+		if val, ok := config.ResolvedEnv[meta.Password]; ok && val != "" {
+			meta.Password = val
 		}
 	}
 
-	if meta.password == "" {
-		return nil, fmt.Errorf("password cannot be empty")
-	}
-
-	meta.metricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("activemq-%s", meta.destinationName)))
-
-	meta.triggerIndex = config.TriggerIndex
+	meta.metricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("activemq-%s", meta.DestinationName)))
 
 	return &meta, nil
 }
 
 // getRestAPIParameters parse restAPITemplate to provide managementEndpoint, brokerName, destinationName
 func getRestAPIParameters(meta activeMQMetadata) (activeMQMetadata, error) {
-	u, err := url.ParseRequestURI(meta.restAPITemplate)
+	u, err := url.ParseRequestURI(meta.RestAPITemplate)
 	if err != nil {
+		// the url.Error would include the template, which may contain credentials
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
 		return meta, fmt.Errorf("unable to parse ActiveMQ restAPITemplate: %w", err)
 	}
+	if !strings.Contains(u.Path, ":") {
+		return meta, errors.New("unable to parse ActiveMQ restAPITemplate: missing the broker object name")
+	}
 
-	meta.managementEndpoint = u.Host
+	meta.ManagementEndpoint = u.Host
 	splitURL := strings.Split(strings.Split(u.Path, ":")[1], "/")[0] // This returns : type=Broker,brokerName=<<brokerName>>,destinationType=Queue,destinationName=<<destinationName>>
 	replacer := strings.NewReplacer(",", "&")
 	v, err := url.ParseQuery(replacer.Replace(splitURL)) // This returns a map with key: string types and element type [] string. : map[brokerName:[<<brokerName>>] destinationName:[<<destinationName>>] destinationType:[Queue] type:[Broker]]
@@ -182,15 +160,15 @@ func getRestAPIParameters(meta activeMQMetadata) (activeMQMetadata, error) {
 		return meta, fmt.Errorf("unable to parse ActiveMQ restAPITemplate: %w", err)
 	}
 
-	if len(v["destinationName"][0]) == 0 {
+	if len(v.Get("destinationName")) == 0 {
 		return meta, errors.New("no destinationName is given")
 	}
-	meta.destinationName = v["destinationName"][0]
+	meta.DestinationName = v.Get("destinationName")
 
-	if len(v["brokerName"][0]) == 0 {
-		return meta, fmt.Errorf("no brokerName given: %s", meta.restAPITemplate)
+	if len(v.Get("brokerName")) == 0 {
+		return meta, errors.New("no brokerName given")
 	}
-	meta.brokerName = v["brokerName"][0]
+	meta.BrokerName = v.Get("brokerName")
 
 	return meta, nil
 }
@@ -198,11 +176,11 @@ func getRestAPIParameters(meta activeMQMetadata) (activeMQMetadata, error) {
 func (s *activeMQScaler) getMonitoringEndpoint() (string, error) {
 	var buf bytes.Buffer
 	endpoint := map[string]string{
-		"ManagementEndpoint": s.metadata.managementEndpoint,
-		"BrokerName":         s.metadata.brokerName,
-		"DestinationName":    s.metadata.destinationName,
+		"ManagementEndpoint": s.metadata.ManagementEndpoint,
+		"BrokerName":         s.metadata.BrokerName,
+		"DestinationName":    s.metadata.DestinationName,
 	}
-	template, err := template.New("monitoring_endpoint").Parse(s.metadata.restAPITemplate)
+	template, err := template.New("monitoring_endpoint").Parse(s.metadata.RestAPITemplate)
 	if err != nil {
 		return "", fmt.Errorf("error parsing template: %w", err)
 	}
@@ -230,9 +208,9 @@ func (s *activeMQScaler) getQueueMessageCount(ctx context.Context) (int64, error
 	}
 
 	// Add HTTP Auth and Headers
-	req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Origin", s.metadata.corsHeader)
+	req.Header.Set("Origin", s.metadata.CorsHeader)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -250,7 +228,7 @@ func (s *activeMQScaler) getQueueMessageCount(ctx context.Context) (int64, error
 		return -1, fmt.Errorf("ActiveMQ management endpoint response error code : %d %d", resp.StatusCode, monitoringInfo.Status)
 	}
 
-	s.logger.V(1).Info(fmt.Sprintf("ActiveMQ scaler: Providing metrics based on current queue size %d queue size limit %d", queueMessageCount, s.metadata.targetQueueSize))
+	s.logger.V(1).Info(fmt.Sprintf("ActiveMQ scaler: Providing metrics based on current queue size %d queue size limit %d", queueMessageCount, s.metadata.TargetQueueSize))
 
 	return queueMessageCount, nil
 }
@@ -261,7 +239,7 @@ func (s *activeMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpe
 		Metric: v2.MetricIdentifier{
 			Name: s.metadata.metricName,
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.targetQueueSize),
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetQueueSize),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -277,7 +255,7 @@ func (s *activeMQScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 
 	metric := GenerateMetricInMili(metricName, float64(queueSize))
 
-	return []external_metrics.ExternalMetricValue{metric}, queueSize > s.metadata.activationTargetQueueSize, nil
+	return []external_metrics.ExternalMetricValue{metric}, queueSize > s.metadata.ActivationTargetQueueSize, nil
 }
 
 func (s *activeMQScaler) Close(context.Context) error {
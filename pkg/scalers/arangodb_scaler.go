@@ -3,8 +3,7 @@ package scalers
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
+	"net/url"
 
 	driver "github.com/arangodb/go-driver"
 	"github.com/arangodb/go-driver/http"
@@ -33,41 +32,66 @@ type dbResult struct {
 type arangoDBMetadata struct {
 	// Specify arangoDB server endpoint URL or comma separated URL endpoints of all the coordinators.
 	// +required
-	endpoints string
+	Endpoints []string `keda:"name=endpoints, order=authParams;triggerMetadata"`
 	// Authentication parameters for connecting to the database
 	// +required
 	arangoDBAuth *authentication.AuthMeta
 	// Specify the unique arangoDB server ID. Only required if bearer JWT is being used.
 	// +optional
-	serverID string
+	ServerID string `keda:"name=serverID, order=triggerMetadata, optional"`
 
 	// The name of the database to be queried.
 	// +required
-	dbName string
+	DBName string `keda:"name=dbName, order=authParams;triggerMetadata"`
 	// The name of the collection to be queried.
 	// +required
-	collection string
+	Collection string `keda:"name=collection, order=triggerMetadata"`
 	// The arangoDB query to be executed.
 	// +required
-	query string
+	Query string `keda:"name=query, order=triggerMetadata"`
 	// A threshold that is used as targetAverageValue in HPA.
 	// +required
-	queryValue float64
+	QueryValue *float64 `keda:"name=queryValue;threshold, order=triggerMetadata, optional"`
 	// A threshold that is used to check if scaler is active.
 	// +optional
-	activationQueryValue float64
+	ActivationQueryValue float64 `keda:"name=activationQueryValue, order=triggerMetadata, default=0"`
 	// Specify whether to verify the server's certificate chain and host name.
 	// +optional
-	unsafeSsl bool
+	UnsafeSsl bool `keda:"name=unsafeSsl, order=triggerMetadata, default=false"`
 	// Specify the max size of the active connection pool.
 	// +optional
-	connectionLimit int64
+	ConnectionLimit *int64 `keda:"name=connectionLimit, order=triggerMetadata, optional, min=1"`
+
+	// When the scaler is used as metric source of a composite scaler, the queryValue isn't required
+	// +internal
+	asMetricSource bool
 
 	// The index of the scaler inside the ScaledObject
 	// +internal
 	triggerIndex int
 }
 
+func (m *arangoDBMetadata) Validate() error {
+	for i, endpoint := range m.Endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("endpoint %d must be an absolute URL with scheme and host", i)
+		}
+	}
+	if m.QueryValue == nil && !m.asMetricSource {
+		return fmt.Errorf("no queryValue given")
+	}
+	return nil
+}
+
+// targetValue returns the queryValue, it's only unset when the scaler is used as a metric source
+func (m *arangoDBMetadata) targetValue() float64 {
+	if m.QueryValue == nil {
+		return 0
+	}
+	return *m.QueryValue
+}
+
 // NewArangoDBScaler creates a new arangodbScaler
 func NewArangoDBScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -97,17 +121,19 @@ func getNewArangoDBClient(meta *arangoDBMetadata) (driver.Client, error) {
 	var auth driver.Authentication
 
 	conn, err := http.NewConnection(http.ConnectionConfig{
-		Endpoints: strings.Split(meta.endpoints, ","),
-		TLSConfig: util.CreateTLSClientConfig(meta.unsafeSsl),
+		Endpoints: meta.Endpoints,
+		TLSConfig: util.CreateTLSClientConfig(meta.UnsafeSsl),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a new http connection, %w", err)
 	}
 
-	if meta.arangoDBAuth.EnableBasicAuth {
+	switch {
+	case meta.arangoDBAuth == nil:
+	case meta.arangoDBAuth.EnableBasicAuth:
 		auth = driver.BasicAuthentication(meta.arangoDBAuth.Username, meta.arangoDBAuth.Password)
-	} else if meta.arangoDBAuth.EnableBearerAuth {
-		hdr, err := jwt.CreateArangodJwtAuthorizationHeader(meta.arangoDBAuth.BearerToken, meta.serverID)
+	case meta.arangoDBAuth.EnableBearerAuth:
+		hdr, err := jwt.CreateArangodJwtAuthorizationHeader(meta.arangoDBAuth.BearerToken, meta.ServerID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create bearer token authorization header, %w", err)
 		}
@@ -127,72 +153,9 @@ func getNewArangoDBClient(meta *arangoDBMetadata) (driver.Client, error) {
 }
 
 func parseArangoDBMetadata(config *scalersconfig.ScalerConfig) (*arangoDBMetadata, error) {
-	// setting default metadata
-	meta := arangoDBMetadata{}
-
-	// parse metaData from ScaledJob config
-	endpoints, err := GetFromAuthOrMeta(config, "endpoints")
-	if err != nil {
-		return nil, err
-	}
-	meta.endpoints = endpoints
-
-	if val, ok := config.TriggerMetadata["collection"]; ok {
-		meta.collection = val
-	} else {
-		return nil, fmt.Errorf("no collection given")
-	}
-
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		meta.query = val
-	} else {
-		return nil, fmt.Errorf("no query given")
-	}
-
-	if val, ok := config.TriggerMetadata["queryValue"]; ok {
-		queryValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert queryValue to int, %w", err)
-		}
-		meta.queryValue = queryValue
-	} else {
-		if config.AsMetricSource {
-			meta.queryValue = 0
-		} else {
-			return nil, fmt.Errorf("no queryValue given")
-		}
-	}
-
-	meta.activationQueryValue = 0
-	if val, ok := config.TriggerMetadata["activationQueryValue"]; ok {
-		activationQueryValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert activationQueryValue to int, %w", err)
-		}
-		meta.activationQueryValue = activationQueryValue
-	}
-
-	dbName, err := GetFromAuthOrMeta(config, "dbName")
-	if err != nil {
-		return nil, err
-	}
-	meta.dbName = dbName
-
-	meta.unsafeSsl = false
-	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok && val != "" {
-		unsafeSslValue, err := strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse unsafeSsl, %w", err)
-		}
-		meta.unsafeSsl = unsafeSslValue
-	}
-
-	if val, ok := config.TriggerMetadata["connectionLimit"]; ok {
-		connectionLimit, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert connectionLimit to int, %w", err)
-		}
-		meta.connectionLimit = connectionLimit
+	meta := arangoDBMetadata{asMetricSource: config.AsMetricSource}
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, fmt.Errorf("error parsing arangodb metadata: %w", err)
 	}
 
 	// parse auth configs from ScalerConfig
@@ -200,6 +163,9 @@ func parseArangoDBMetadata(config *scalersconfig.ScalerConfig) (*arangoDBMetadat
 	if err != nil {
 		return nil, err
 	}
+	if arangoDBAuth != nil && arangoDBAuth.EnableBasicAuth && arangoDBAuth.EnableBearerAuth {
+		return nil, fmt.Errorf("only one of basic or bearer authModes can be used at a time")
+	}
 	meta.arangoDBAuth = arangoDBAuth
 
 	meta.triggerIndex = config.TriggerIndex
@@ -212,32 +178,32 @@ func (s *arangoDBScaler) Close(_ context.Context) error {
 }
 
 func (s *arangoDBScaler) getQueryResult(ctx context.Context) (float64, error) {
-	dbExists, err := s.client.DatabaseExists(ctx, s.metadata.dbName)
+	dbExists, err := s.client.DatabaseExists(ctx, s.metadata.DBName)
 	if err != nil {
-		return -1, fmt.Errorf("failed to check if %s database exists, %w", s.metadata.dbName, err)
+		return -1, fmt.Errorf("failed to check if %s database exists, %w", s.metadata.DBName, err)
 	}
 
 	if !dbExists {
-		return -1, fmt.Errorf("%s database not found", s.metadata.dbName)
+		return -1, fmt.Errorf("%s database not found", s.metadata.DBName)
 	}
 
-	db, err := s.client.Database(ctx, s.metadata.dbName)
+	db, err := s.client.Database(ctx, s.metadata.DBName)
 	if err != nil {
-		return -1, fmt.Errorf("failed to connect to %s db, %w", s.metadata.dbName, err)
+		return -1, fmt.Errorf("failed to connect to %s db, %w", s.metadata.DBName, err)
 	}
 
-	collectionExists, err := db.CollectionExists(ctx, s.metadata.collection)
+	collectionExists, err := db.CollectionExists(ctx, s.metadata.Collection)
 	if err != nil {
-		return -1, fmt.Errorf("failed to check if %s collection exists, %w", s.metadata.collection, err)
+		return -1, fmt.Errorf("failed to check if %s collection exists, %w", s.metadata.Collection, err)
 	}
 
 	if !collectionExists {
-		return -1, fmt.Errorf("%s collection not found in %s database", s.metadata.collection, s.metadata.dbName)
+		return -1, fmt.Errorf("%s collection not found in %s database", s.metadata.Collection, s.metadata.DBName)
 	}
 
 	ctx = driver.WithQueryCount(ctx)
 
-	cursor, err := db.Query(ctx, s.metadata.query, nil)
+	cursor, err := db.Query(ctx, s.metadata.Query, nil)
 	if err != nil {
 		return -1, fmt.Errorf("failed to execute the query, %w", err)
 	}
@@ -265,7 +231,7 @@ func (s *arangoDBScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 
 	metric := GenerateMetricInMili(metricName, num)
 
-	return append([]external_metrics.ExternalMetricValue{}, metric), num > s.metadata.activationQueryValue, nil
+	return append([]external_metrics.ExternalMetricValue{}, metric), num > s.metadata.ActivationQueryValue, nil
 }
 
 // GetMetricSpecForScaling get the query value for scaling
@@ -274,7 +240,7 @@ func (s *arangoDBScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpe
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, "arangodb"),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.queryValue),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue()),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
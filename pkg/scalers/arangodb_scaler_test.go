@@ -11,9 +11,11 @@ import (
 )
 
 type parseArangoDBMetadataTestData struct {
-	metadata    map[string]string
-	authParams  map[string]string
-	raisesError bool
+	metadata       map[string]string
+	authParams     map[string]string
+	asMetricSource bool
+	raisesError    bool
+	parity         *metadataParity
 }
 
 var testArangoDBMetadata = []parseArangoDBMetadataTestData{
@@ -47,6 +49,74 @@ var testArangoDBMetadata = []parseArangoDBMetadataTestData{
 		authParams:  map[string]string{},
 		raisesError: true,
 	},
+	// missing queryValue
+	{
+		metadata:    map[string]string{"endpoints": "https://localhost:8529", "query": `FOR t IN testCollection RETURN t`, "collection": "demo", "dbName": "test"},
+		authParams:  map[string]string{},
+		raisesError: true,
+	},
+	// empty query
+	{
+		metadata:    map[string]string{"endpoints": "https://localhost:8529", "query": "", "collection": "demo", "queryValue": "12", "dbName": "test"},
+		authParams:  map[string]string{},
+		raisesError: true,
+	},
+	// one of the endpoints is not an absolute URL
+	{
+		metadata:    map[string]string{"endpoints": "https://localhost:8529,localhost:8530", "query": `FOR t IN testCollection RETURN t`, "collection": "demo", "queryValue": "12", "dbName": "test"},
+		authParams:  map[string]string{},
+		raisesError: true,
+	},
+	// connectionLimit not greater than 0
+	{
+		metadata:    map[string]string{"endpoints": "https://localhost:8529", "query": `FOR t IN testCollection RETURN t`, "collection": "demo", "queryValue": "12", "dbName": "test", "connectionLimit": "0"},
+		authParams:  map[string]string{},
+		raisesError: true,
+	},
+	// threshold alias of queryValue
+	{
+		metadata:    map[string]string{"endpoints": "https://localhost:8529", "query": `FOR t IN testCollection RETURN t`, "collection": "demo", "threshold": "12", "dbName": "test"},
+		authParams:  map[string]string{},
+		raisesError: false,
+	},
+	// queryValue takes precedence over threshold
+	{
+		metadata:    map[string]string{"endpoints": "https://localhost:8529", "query": "q", "collection": "demo", "queryValue": "12", "threshold": "7", "dbName": "test"},
+		raisesError: false,
+		parity:      &metadataParity{fields: map[string]any{"QueryValue": 12}},
+	},
+	// threshold alias
+	{
+		metadata:    map[string]string{"endpoints": "https://localhost:8529", "query": "q", "collection": "demo", "threshold": "7", "dbName": "test"},
+		raisesError: false,
+		parity:      &metadataParity{fields: map[string]any{"QueryValue": 7}},
+	},
+	// dbName and endpoints from authParams take precedence
+	{
+		metadata:    map[string]string{"endpoints": "https://localhost:8529", "query": "q", "collection": "demo", "queryValue": "1", "dbName": "test"},
+		authParams:  map[string]string{"endpoints": "https://db-0:8529,https://db-1:8529", "dbName": "auth-test"},
+		raisesError: false,
+		parity:      &metadataParity{fields: map[string]any{"DBName": "auth-test", "Endpoints": []string{"https://db-0:8529", "https://db-1:8529"}}},
+	},
+	// queryValue isn't required as metric source
+	{
+		metadata:       map[string]string{"endpoints": "https://localhost:8529", "query": "q", "collection": "demo", "dbName": "test"},
+		asMetricSource: true,
+		raisesError:    false,
+		parity:         &metadataParity{fields: map[string]any{"QueryValue": nil}},
+	},
+	// explicit zero queryValue
+	{
+		metadata:    map[string]string{"endpoints": "https://localhost:8529", "query": "q", "collection": "demo", "queryValue": "0", "dbName": "test"},
+		raisesError: false,
+		parity:      &metadataParity{fields: map[string]any{"QueryValue": 0}},
+	},
+	// defaults
+	{
+		metadata:    map[string]string{"endpoints": "https://localhost:8529", "query": "q", "collection": "demo", "queryValue": "1", "dbName": "test", "serverID": "PRMR-1", "connectionLimit": "5"},
+		raisesError: false,
+		parity:      &metadataParity{fields: map[string]any{"ActivationQueryValue": 0, "UnsafeSsl": false, "ServerID": "PRMR-1", "ConnectionLimit": 5}},
+	},
 }
 
 type arangoDBAuthMetadataTestData struct {
@@ -66,6 +136,8 @@ var testArangoDBAuthMetadata = []arangoDBAuthMetadataTestData{
 	{map[string]string{"endpoints": "https://http://34.162.13.192:8529,https://34.162.13.193:8529", "collection": "demo", "query": "FOR d IN myCollection RETURN d", "queryValue": "1", "dbName": "testdb", "authModes": "basic"}, map[string]string{}, true},
 	// success basicAuth with no password
 	{map[string]string{"endpoints": "https://http://34.162.13.192:8529,https://34.162.13.193:8529", "collection": "demo", "query": "FOR d IN myCollection RETURN d", "queryValue": "1", "dbName": "testdb", "authModes": "basic"}, map[string]string{"username": "user"}, false},
+	// fail basicAuth combined with bearerAuth
+	{map[string]string{"endpoints": "https://34.162.13.193:8529", "collection": "demo", "query": "FOR d IN myCollection RETURN d", "queryValue": "1", "dbName": "testdb", "authModes": "basic,bearer"}, map[string]string{"username": "user", "password": "pass", "bearerToken": "dummy-token"}, true},
 }
 
 type arangoDBMetricIdentifier struct {
@@ -81,13 +153,14 @@ var arangoDBMetricIdentifiers = []arangoDBMetricIdentifier{
 
 func TestParseArangoDBMetadata(t *testing.T) {
 	for _, testData := range testArangoDBMetadata {
-		_, err := parseArangoDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		meta, err := parseArangoDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, AsMetricSource: testData.asMetricSource})
 		if err != nil && !testData.raisesError {
 			t.Error("Expected success but got error:", err)
 		}
 		if err == nil && testData.raisesError {
 			t.Error("Expected error but got success")
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
 	}
 }
 
@@ -7,7 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -27,17 +28,20 @@ type artemisScaler struct {
 
 //revive:disable:var-naming breaking change on restApiTemplate, wouldn't bring any benefit to users
 type artemisMetadata struct {
-	managementEndpoint    string
-	queueName             string
-	brokerName            string
-	brokerAddress         string
-	username              string
-	password              string
-	restAPITemplate       string
-	queueLength           int64
-	activationQueueLength int64
-	corsHeader            string
-	triggerIndex          int
+	// the endpoint, queue and broker fields are only required without restApiTemplate,
+	// otherwise they are parsed from the template
+	ManagementEndpoint    string `keda:"name=managementEndpoint,    order=triggerMetadata, optional"`
+	QueueName             string `keda:"name=queueName,             order=triggerMetadata, optional"`
+	BrokerName            string `keda:"name=brokerName,            order=triggerMetadata, optional"`
+	BrokerAddress         string `keda:"name=brokerAddress,         order=triggerMetadata, optional"`
+	Username              string `keda:"name=username,              order=authParams;resolvedEnv;triggerMetadata"`
+	Password              string `keda:"name=password,              order=authParams;resolvedEnv;triggerMetadata, sensitive"`
+	RestAPITemplate       string `keda:"name=restApiTemplate,       order=triggerMetadata, optional"`
+	QueueLength           int64  `keda:"name=queueLength,           order=triggerMetadata, default=10"`
+	ActivationQueueLength int64  `keda:"name=activationQueueLength, order=triggerMetadata, default=0"`
+	CorsHeader            string `keda:"name=corsHeader,            order=triggerMetadata, optional"`
+
+	triggerIndex int
 }
 
 //revive:enable:var-naming
@@ -49,11 +53,9 @@ type artemisMonitoring struct {
 }
 
 const (
-	artemisMetricType                   = "External"
-	defaultArtemisQueueLength           = 10
-	defaultArtemisActivationQueueLength = 0
-	defaultRestAPITemplate              = "http://<<managementEndpoint>>/console/jolokia/read/org.apache.activemq.artemis:broker=\"<<brokerName>>\",component=addresses,address=\"<<brokerAddress>>\",subcomponent=queues,routing-type=\"anycast\",queue=\"<<queueName>>\"/MessageCount"
-	defaultCorsHeader                   = "http://%s"
+	artemisMetricType      = "External"
+	defaultRestAPITemplate = "http://<<managementEndpoint>>/console/jolokia/read/org.apache.activemq.artemis:broker=\"<<brokerName>>\",component=addresses,address=\"<<brokerAddress>>\",subcomponent=queues,routing-type=\"anycast\",queue=\"<<queueName>>\"/MessageCount"
+	defaultCorsHeader      = "http://%s"
 )
 
 // NewArtemisQueueScaler creates a new artemis queue Scaler
@@ -81,109 +83,81 @@ func NewArtemisQueueScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	}, nil
 }
 
-func parseArtemisMetadata(config *scalersconfig.ScalerConfig) (*artemisMetadata, error) {
-	meta := artemisMetadata{}
-
-	meta.queueLength = defaultArtemisQueueLength
-	meta.activationQueueLength = defaultArtemisActivationQueueLength
-
-	if val, ok := config.TriggerMetadata["restApiTemplate"]; ok && val != "" {
-		meta.restAPITemplate = config.TriggerMetadata["restApiTemplate"]
-		var err error
-		if meta, err = getAPIParameters(meta); err != nil {
-			return nil, fmt.Errorf("can't parse restApiTemplate : %s ", err)
-		}
-	} else {
-		meta.restAPITemplate = defaultRestAPITemplate
-		if config.TriggerMetadata["managementEndpoint"] == "" {
-			return nil, errors.New("no management endpoint given")
-		}
-		meta.managementEndpoint = config.TriggerMetadata["managementEndpoint"]
-
-		if config.TriggerMetadata["queueName"] == "" {
-			return nil, errors.New("no queue name given")
-		}
-		meta.queueName = config.TriggerMetadata["queueName"]
-
-		if config.TriggerMetadata["brokerName"] == "" {
-			return nil, errors.New("no broker name given")
-		}
-		meta.brokerName = config.TriggerMetadata["brokerName"]
-
-		if config.TriggerMetadata["brokerAddress"] == "" {
-			return nil, errors.New("no broker address given")
-		}
-		meta.brokerAddress = config.TriggerMetadata["brokerAddress"]
+func (m *artemisMetadata) Validate() error {
+	if m.RestAPITemplate != "" {
+		return nil
 	}
-
-	if val, ok := config.TriggerMetadata["corsHeader"]; ok && val != "" {
-		meta.corsHeader = config.TriggerMetadata["corsHeader"]
-	} else {
-		meta.corsHeader = fmt.Sprintf(defaultCorsHeader, meta.managementEndpoint)
+	if m.ManagementEndpoint == "" {
+		return errors.New("no management endpoint given")
 	}
-
-	if val, ok := config.TriggerMetadata["queueLength"]; ok {
-		queueLength, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("can't parse queueLength: %w", err)
-		}
-
-		meta.queueLength = queueLength
+	if m.QueueName == "" {
+		return errors.New("no queue name given")
 	}
-
-	if val, ok := config.TriggerMetadata["activationQueueLength"]; ok {
-		activationQueueLength, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("can't parse activationQueueLength: %w", err)
-		}
-
-		meta.activationQueueLength = activationQueueLength
+	if m.BrokerName == "" {
+		return errors.New("no broker name given")
 	}
-
-	if val, ok := config.AuthParams["username"]; ok && val != "" {
-		meta.username = val
-	} else if val, ok := config.TriggerMetadata["username"]; ok && val != "" {
-		username := val
-
-		if val, ok := config.ResolvedEnv[username]; ok && val != "" {
-			meta.username = val
-		} else {
-			meta.username = username
-		}
+	if m.BrokerAddress == "" {
+		return errors.New("no broker address given")
 	}
+	return nil
+}
 
-	if meta.username == "" {
-		return nil, fmt.Errorf("username cannot be empty")
+func parseArtemisMetadata(config *scalersconfig.ScalerConfig) (*artemisMetadata, error) {
+	meta := artemisMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, err
 	}
 
-	if val, ok := config.AuthParams["password"]; ok && val != "" {
-		meta.password = val
-	} else if val, ok := config.TriggerMetadata["password"]; ok && val != "" {
-		password := val
-
-		if val, ok := config.ResolvedEnv[password]; ok && val != "" {
-			meta.password = val
-		} else {
-			meta.password = password
+	if meta.RestAPITemplate != "" {
+		// the template takes precedence over the individual endpoint, queue and broker fields
+		var err error
+		if meta, err = getAPIParameters(meta); err != nil {
+			return nil, fmt.Errorf("can't parse restApiTemplate : %w", err)
 		}
+	} else {
+		meta.RestAPITemplate = defaultRestAPITemplate
 	}
 
-	if meta.password == "" {
-		return nil, fmt.Errorf("password cannot be empty")
+	if meta.CorsHeader == "" {
+		meta.CorsHeader = fmt.Sprintf(defaultCorsHeader, meta.ManagementEndpoint)
 	}
 
-	meta.triggerIndex = config.TriggerIndex
+	resolveArtemisCredential(config, "username", &meta.Username)
+	resolveArtemisCredential(config, "password", &meta.Password)
 
 	return &meta, nil
 }
 
+// resolveArtemisCredential replaces the credential from trigger metadata with the environment variable of
+// the scale target it names, for backwards compatibility, the credentials from other sources are kept
+func resolveArtemisCredential(config *scalersconfig.ScalerConfig, name string, credential *string) {
+	_, source, found, err := config.GetStringSource(name, scalersconfig.AuthParams, scalersconfig.ResolvedEnv, scalersconfig.TriggerMetadata)
+	if err != nil || !found || source != scalersconfig.TriggerMetadata {
+		return
+	}
+	if val, ok := config.ResolvedEnv[*credential]; ok && val != "" {
+		*credential = val
+	}
+}
+
 // getAPIParameters parse restAPITemplate to provide managementEndpoint , brokerName, brokerAddress, queueName
 func getAPIParameters(meta artemisMetadata) (artemisMetadata, error) {
-	u, err := url.ParseRequestURI(meta.restAPITemplate)
+	if placeholders := unknownArtemisPlaceholders(meta.RestAPITemplate); len(placeholders) > 0 {
+		return meta, fmt.Errorf("unknown placeholders %v, supported are %v", placeholders, artemisPlaceholders)
+	}
+	u, err := url.ParseRequestURI(meta.RestAPITemplate)
 	if err != nil {
+		// the url.Error would include the template, which may contain credentials
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
 		return meta, fmt.Errorf("unable to parse the artemis restAPITemplate: %w", err)
 	}
-	meta.managementEndpoint = u.Host
+	if !strings.Contains(u.RawPath, ":") {
+		return meta, errors.New("unable to parse the artemis restAPITemplate: missing the broker object name")
+	}
+	meta.ManagementEndpoint = u.Host
 	splitURL := strings.Split(strings.Split(u.RawPath, ":")[1], "/")[0] // This returns : broker="<<brokerName>>",component=addresses,address="<<brokerAddress>>",subcomponent=queues,routing-type="anycast",queue="<<queueName>>"
 	replacer := strings.NewReplacer(",", "&", "\"\"", "")
 	v, err := url.ParseQuery(replacer.Replace(splitURL)) // This returns a map with key: string types and element type [] string. : map[address:["<<brokerAddress>>"] broker:["<<brokerName>>"] component:[addresses] queue:["<<queueName>>"] routing-type:["anycast"] subcomponent:[queues]]
@@ -191,31 +165,47 @@ func getAPIParameters(meta artemisMetadata) (artemisMetadata, error) {
 		return meta, fmt.Errorf("unable to parse the artemis restAPITemplate: %w", err)
 	}
 
-	if len(v["address"][0]) == 0 {
+	if len(v.Get("address")) == 0 {
 		return meta, errors.New("no brokerAddress given")
 	}
-	meta.brokerAddress = v["address"][0]
+	meta.BrokerAddress = v.Get("address")
 
-	if len(v["queue"][0]) == 0 {
+	if len(v.Get("queue")) == 0 {
 		return meta, errors.New("no queueName is given")
 	}
-	meta.queueName = v["queue"][0]
+	meta.QueueName = v.Get("queue")
 
-	if len(v["broker"][0]) == 0 {
-		return meta, fmt.Errorf("no brokerName given: %s", meta.restAPITemplate)
+	if len(v.Get("broker")) == 0 {
+		return meta, errors.New("no brokerName given")
 	}
-	meta.brokerName = v["broker"][0]
+	meta.BrokerName = v.Get("broker")
 
 	return meta, nil
 }
 
+// artemisPlaceholders are the placeholders replaced in the restApiTemplate
+var artemisPlaceholders = []string{"<<managementEndpoint>>", "<<queueName>>", "<<brokerName>>", "<<brokerAddress>>"}
+
+var artemisPlaceholderRegex = regexp.MustCompile(`<<[^<>]*>>`)
+
+// unknownArtemisPlaceholders returns the placeholders from the template that wouldn't be replaced
+func unknownArtemisPlaceholders(template string) []string {
+	var unknown []string
+	for _, p := range artemisPlaceholderRegex.FindAllString(template, -1) {
+		if !slices.Contains(artemisPlaceholders, p) {
+			unknown = append(unknown, p)
+		}
+	}
+	return unknown
+}
+
 func (s *artemisScaler) getMonitoringEndpoint() string {
-	replacer := strings.NewReplacer("<<managementEndpoint>>", s.metadata.managementEndpoint,
-		"<<queueName>>", s.metadata.queueName,
-		"<<brokerName>>", s.metadata.brokerName,
-		"<<brokerAddress>>", s.metadata.brokerAddress)
+	replacer := strings.NewReplacer("<<managementEndpoint>>", s.metadata.ManagementEndpoint,
+		"<<queueName>>", s.metadata.QueueName,
+		"<<brokerName>>", s.metadata.BrokerName,
+		"<<brokerAddress>>", s.metadata.BrokerAddress)
 
-	monitoringEndpoint := replacer.Replace(s.metadata.restAPITemplate)
+	monitoringEndpoint := replacer.Replace(s.metadata.RestAPITemplate)
 
 	return monitoringEndpoint
 }
@@ -231,8 +221,8 @@ func (s *artemisScaler) getQueueMessageCount(ctx context.Context) (int64, error)
 	if err != nil {
 		return -1, err
 	}
-	req.SetBasicAuth(s.metadata.username, s.metadata.password)
-	req.Header.Set("Origin", s.metadata.corsHeader)
+	req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	req.Header.Set("Origin", s.metadata.CorsHeader)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -250,7 +240,7 @@ func (s *artemisScaler) getQueueMessageCount(ctx context.Context) (int64, error)
 		return -1, fmt.Errorf("artemis management endpoint response error code : %d %d", resp.StatusCode, monitoringInfo.Status)
 	}
 
-	s.logger.V(1).Info(fmt.Sprintf("Artemis scaler: Providing metrics based on current queue length %d queue length limit %d", messageCount, s.metadata.queueLength))
+	s.logger.V(1).Info(fmt.Sprintf("Artemis scaler: Providing metrics based on current queue length %d queue length limit %d", messageCount, s.metadata.QueueLength))
 
 	return messageCount, nil
 }
@@ -258,9 +248,9 @@ func (s *artemisScaler) getQueueMessageCount(ctx context.Context) (int64, error)
 func (s *artemisScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("artemis-%s", s.metadata.queueName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("artemis-%s", s.metadata.QueueName))),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.queueLength),
+		Target: GetMetricTarget(s.metricType, s.metadata.QueueLength),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: artemisMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -271,13 +261,13 @@ func (s *artemisScaler) GetMetricsAndActivity(ctx context.Context, metricName st
 	messages, err := s.getQueueMessageCount(ctx)
 
 	if err != nil {
-		s.logger.Error(err, "Unable to access the artemis management endpoint", "managementEndpoint", s.metadata.managementEndpoint)
+		s.logger.Error(err, "Unable to access the artemis management endpoint", "managementEndpoint", s.metadata.ManagementEndpoint)
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
 	metric := GenerateMetricInMili(metricName, float64(messages))
 
-	return []external_metrics.ExternalMetricValue{metric}, messages > s.metadata.activationQueueLength, nil
+	return []external_metrics.ExternalMetricValue{metric}, messages > s.metadata.ActivationQueueLength, nil
 }
 
 func (s *artemisScaler) Close(context.Context) error {
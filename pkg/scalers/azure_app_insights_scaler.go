@@ -16,32 +16,83 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
-const (
-	azureAppInsightsMetricID                      = "metricId"
-	azureAppInsightsTargetValueName               = "targetValue"
-	azureAppInsightsActivationTargetValueName     = "activationTargetValue"
-	azureAppInsightsAppIDName                     = "applicationInsightsId"
-	azureAppInsightsMetricAggregationTimespanName = "metricAggregationTimespan"
-	azureAppInsightsMetricAggregationTypeName     = "metricAggregationType"
-	azureAppInsightsMetricFilterName              = "metricFilter"
-	azureAppInsightsTenantIDName                  = "tenantId"
-	azureAppInsightsIgnoreNullValues              = "ignoreNullValues"
-)
-
-var (
-	azureAppInsightsDefaultIgnoreNullValues = false
-)
-
 type azureAppInsightsMetadata struct {
-	azureAppInsightsInfo  azure.AppInsightsInfo
-	targetValue           float64
-	activationTargetValue float64
-	triggerIndex          int
+	TargetValue               *float64 `keda:"name=targetValue,               order=triggerMetadata;resolvedEnv, optional"`
+	ActivationTargetValue     float64  `keda:"name=activationTargetValue,     order=triggerMetadata;resolvedEnv, default=0"`
+	ApplicationInsightsID     string   `keda:"name=applicationInsightsId,     order=authParams;triggerMetadata;resolvedEnv"`
+	TenantID                  string   `keda:"name=tenantId,                  order=authParams;triggerMetadata;resolvedEnv"`
+	MetricID                  string   `keda:"name=metricId,                  order=triggerMetadata;resolvedEnv"`
+	MetricAggregationTimespan string   `keda:"name=metricAggregationTimespan, order=triggerMetadata;resolvedEnv"`
+	MetricAggregationType     string   `keda:"name=metricAggregationType,     order=triggerMetadata;resolvedEnv, enum=avg;count;max;min;sum;unique"`
+	MetricFilter              string   `keda:"name=metricFilter,              order=triggerMetadata, optional"`
+	Cloud                     string   `keda:"name=cloud,                     order=triggerMetadata, default=azurePublicCloud"`
+	AppInsightsResourceURL    string   `keda:"name=appInsightsResourceURL,    order=triggerMetadata, optional"`
+	// ClientID and ClientPassword are only used without pod identity
+	ClientID       string `keda:"name=activeDirectoryClientId,       order=authParams;triggerMetadata;resolvedEnv, optional"`
+	ClientPassword string `keda:"name=activeDirectoryClientPassword, order=authParams;resolvedEnv,                 optional, sensitive"`
 	// sometimes we should consider there is an error we can accept
 	// default value is true/t, to ignore the null value returned from prometheus
 	// change to false/f if you can not accept prometheus returning null values
 	// https://github.com/kedacore/keda/issues/4316
-	ignoreNullValues bool
+	IgnoreNullValues bool `keda:"name=ignoreNullValues, order=triggerMetadata, default=false"`
+
+	azureAppInsightsInfo azure.AppInsightsInfo
+	podIdentity          kedav1alpha1.AuthPodIdentity
+	asMetricSource       bool
+	triggerIndex         int
+}
+
+// targetValue returns the targetValue, it's only unset when the scaler is used as a metric source
+func (m *azureAppInsightsMetadata) targetValue() float64 {
+	if m.TargetValue == nil {
+		return 0
+	}
+	return *m.TargetValue
+}
+
+func (m *azureAppInsightsMetadata) Validate() error {
+	if m.TargetValue == nil && !m.asMetricSource {
+		return parameterNotFoundError("targetValue")
+	}
+	if err := validateAppInsightsTimespan(m.MetricAggregationTimespan); err != nil {
+		return err
+	}
+
+	if strings.EqualFold(m.Cloud, azure.PrivateCloud) {
+		if m.AppInsightsResourceURL == "" {
+			return fmt.Errorf("appInsightsResourceURL must be provided for %s cloud type", azure.PrivateCloud)
+		}
+	} else if _, ok := azure.AppInsightsResourceURLInCloud[strings.ToUpper(m.Cloud)]; !ok {
+		return fmt.Errorf("there is no cloud environment matching the name %s", m.Cloud)
+	}
+
+	switch m.podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		if m.ClientID == "" {
+			return fmt.Errorf("no activeDirectoryClientId given")
+		}
+		if m.ClientPassword == "" {
+			return fmt.Errorf("no activeDirectoryClientPassword given")
+		}
+	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+		// the client credentials are ignored with pod identity
+	default:
+		return fmt.Errorf("azure App Insights doesn't support pod identity %s", m.podIdentity.Provider)
+	}
+	return nil
+}
+
+// validateAppInsightsTimespan checks the aggregation timespan is in the hh:mm format, e.g. 01:30
+func validateAppInsightsTimespan(timespan string) error {
+	hours, minutes, found := strings.Cut(timespan, ":")
+	if found {
+		_, herr := strconv.ParseUint(hours, 10, 32)
+		_, merr := strconv.ParseUint(minutes, 10, 32)
+		if herr == nil && merr == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("metricAggregationTimespan not in the correct format. Should be hh:mm, e.g. 01:30, got %q", timespan)
 }
 
 type azureAppInsightsScaler struct {
@@ -75,117 +126,39 @@ func NewAzureAppInsightsScaler(config *scalersconfig.ScalerConfig) (Scaler, erro
 
 func parseAzureAppInsightsMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*azureAppInsightsMetadata, error) {
 	meta := azureAppInsightsMetadata{
-		azureAppInsightsInfo: azure.AppInsightsInfo{},
-	}
-
-	val, err := getParameterFromConfig(config, azureAppInsightsTargetValueName, false)
-	if err != nil {
-		if config.AsMetricSource {
-			meta.targetValue = 0
-		} else {
-			return nil, err
-		}
-	}
-	targetValue, err := strconv.ParseFloat(val, 64)
-	if err != nil {
-		logger.Error(err, "Error parsing azure app insights metadata", azureAppInsightsTargetValueName, azureAppInsightsTargetValueName)
-		return nil, fmt.Errorf("error parsing azure app insights metadata %s: %w", azureAppInsightsTargetValueName, err)
-	}
-	meta.targetValue = targetValue
-
-	meta.activationTargetValue = 0
-	val, err = getParameterFromConfig(config, azureAppInsightsActivationTargetValueName, false)
-	if err == nil {
-		activationTargetValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			logger.Error(err, "Error parsing azure app insights metadata", azureAppInsightsActivationTargetValueName, azureAppInsightsActivationTargetValueName)
-			return nil, fmt.Errorf("error parsing azure app insights metadata %s: %w", azureAppInsightsActivationTargetValueName, err)
-		}
-		meta.activationTargetValue = activationTargetValue
+		podIdentity:    config.PodIdentity,
+		asMetricSource: config.AsMetricSource,
 	}
-
-	val, err = getParameterFromConfig(config, azureAppInsightsMetricID, false)
-	if err != nil {
-		return nil, err
-	}
-	meta.azureAppInsightsInfo.MetricID = val
-
-	val, err = getParameterFromConfig(config, azureAppInsightsMetricAggregationTimespanName, false)
-	if err != nil {
+	if err := config.TypedConfig(&meta); err != nil {
+		logger.V(1).Info("Error parsing azure app insights metadata", "error", err)
 		return nil, err
 	}
-	aggregationTimespan := strings.Split(val, ":")
-	if len(aggregationTimespan) != 2 {
-		return nil, fmt.Errorf("%s not in the correct format. Should be hh:mm", azureAppInsightsMetricAggregationTimespanName)
-	}
-	meta.azureAppInsightsInfo.AggregationTimespan = val
-
-	val, err = getParameterFromConfig(config, azureAppInsightsMetricAggregationTypeName, false)
-	if err != nil {
-		return nil, err
-	}
-	meta.azureAppInsightsInfo.AggregationType = val
-
-	if val, ok := config.TriggerMetadata[azureAppInsightsMetricFilterName]; ok && val != "" {
-		meta.azureAppInsightsInfo.Filter = val
-	} else {
-		meta.azureAppInsightsInfo.Filter = ""
-	}
-
-	meta.azureAppInsightsInfo.AppInsightsResourceURL = azure.DefaultAppInsightsResourceURL
-
-	if cloud, ok := config.TriggerMetadata["cloud"]; ok {
-		if strings.EqualFold(cloud, azure.PrivateCloud) {
-			if resource, ok := config.TriggerMetadata["appInsightsResourceURL"]; ok && resource != "" {
-				meta.azureAppInsightsInfo.AppInsightsResourceURL = resource
-			} else {
-				return nil, fmt.Errorf("appInsightsResourceURL must be provided for %s cloud type", azure.PrivateCloud)
-			}
-		} else if resource, ok := azure.AppInsightsResourceURLInCloud[strings.ToUpper(cloud)]; ok {
-			meta.azureAppInsightsInfo.AppInsightsResourceURL = resource
-		} else {
-			return nil, fmt.Errorf("there is no cloud environment matching the name %s", cloud)
-		}
-	}
 
 	activeDirectoryEndpoint, err := azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
 	if err != nil {
 		return nil, err
 	}
-	meta.azureAppInsightsInfo.ActiveDirectoryEndpoint = activeDirectoryEndpoint
 
-	meta.ignoreNullValues = azureAppInsightsDefaultIgnoreNullValues
-	if val, ok := config.TriggerMetadata[azureAppInsightsIgnoreNullValues]; ok && val != "" {
-		azureAppInsightsIgnoreNullValues, err := strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("err incorrect value for azureAppInsightsIgnoreNullValues given: %s, please use true or false", val)
-		}
-		meta.ignoreNullValues = azureAppInsightsIgnoreNullValues
+	meta.azureAppInsightsInfo = azure.AppInsightsInfo{
+		ApplicationInsightsID:   meta.ApplicationInsightsID,
+		TenantID:                meta.TenantID,
+		MetricID:                meta.MetricID,
+		AggregationTimespan:     meta.MetricAggregationTimespan,
+		AggregationType:         meta.MetricAggregationType,
+		Filter:                  meta.MetricFilter,
+		AppInsightsResourceURL:  meta.AppInsightsResourceURL,
+		ActiveDirectoryEndpoint: activeDirectoryEndpoint,
 	}
-
-	// Required authentication parameters below
-
-	val, err = getParameterFromConfig(config, azureAppInsightsAppIDName, true)
-	if err != nil {
-		return nil, err
+	// the resource URL is only configurable for the private cloud
+	if !strings.EqualFold(meta.Cloud, azure.PrivateCloud) {
+		meta.azureAppInsightsInfo.AppInsightsResourceURL = azure.AppInsightsResourceURLInCloud[strings.ToUpper(meta.Cloud)]
 	}
-	meta.azureAppInsightsInfo.ApplicationInsightsID = val
-
-	val, err = getParameterFromConfig(config, azureAppInsightsTenantIDName, true)
-	if err != nil {
-		return nil, err
+	if meta.podIdentity.Provider == "" || meta.podIdentity.Provider == kedav1alpha1.PodIdentityProviderNone {
+		meta.azureAppInsightsInfo.ClientID = meta.ClientID
+		meta.azureAppInsightsInfo.ClientPassword = meta.ClientPassword
 	}
-	meta.azureAppInsightsInfo.TenantID = val
-
-	clientID, clientPassword, err := parseAzurePodIdentityParams(config)
-	if err != nil {
-		return nil, err
-	}
-	meta.azureAppInsightsInfo.ClientID = clientID
-	meta.azureAppInsightsInfo.ClientPassword = clientPassword
 
 	meta.triggerIndex = config.TriggerIndex
-
 	return &meta, nil
 }
 
@@ -196,9 +169,9 @@ func (s *azureAppInsightsScaler) Close(context.Context) error {
 func (s *azureAppInsightsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("azure-app-insights-%s", s.metadata.azureAppInsightsInfo.MetricID))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("azure-app-insights-%s", s.metadata.MetricID))),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue()),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -206,7 +179,7 @@ func (s *azureAppInsightsScaler) GetMetricSpecForScaling(context.Context) []v2.M
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *azureAppInsightsScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	val, err := azure.GetAzureAppInsightsMetricValue(ctx, s.metadata.azureAppInsightsInfo, s.podIdentity, s.metadata.ignoreNullValues)
+	val, err := azure.GetAzureAppInsightsMetricValue(ctx, s.metadata.azureAppInsightsInfo, s.podIdentity, s.metadata.IgnoreNullValues)
 	if err != nil {
 		s.logger.Error(err, "error getting azure app insights metric")
 		return []external_metrics.ExternalMetricValue{}, false, err
@@ -214,5 +187,5 @@ func (s *azureAppInsightsScaler) GetMetricsAndActivity(ctx context.Context, metr
 
 	metric := GenerateMetricInMili(metricName, val)
 
-	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.activationTargetValue, nil
+	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.ActivationTargetValue, nil
 }
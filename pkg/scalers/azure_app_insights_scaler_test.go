@@ -9,6 +9,7 @@ import (
 	"github.com/go-logr/logr"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
@@ -16,6 +17,7 @@ type azureAppInsightsScalerTestData struct {
 	name    string
 	isError bool
 	config  scalersconfig.ScalerConfig
+	parity  *metadataParity
 }
 
 var azureAppInsightsScalerData = []azureAppInsightsScalerTestData{
@@ -193,6 +195,15 @@ var azureAppInsightsScalerData = []azureAppInsightsScalerTestData{
 			"AD_CLIENT_ID": "5678", "AD_CLIENT_PASSWORD": "pw", "APP_INSIGHTS_ID": "1234", "TENANT_ID": "1234",
 		},
 	}},
+	{name: "explicit zero target value", isError: false, config: scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"metricAggregationTimespan": "00:01", "metricAggregationType": "count", "metricId": "unittest/test", "targetValue": "0",
+			"applicationInsightsId": "appinsightid", "tenantId": "tenantid",
+		},
+		AuthParams: map[string]string{
+			"tenantId": "tenantId", "activeDirectoryClientId": "adClientId", "activeDirectoryClientPassword": "adClientPassword",
+		},
+	}},
 	{name: "known Azure Cloud", isError: false, config: scalersconfig.ScalerConfig{
 		TriggerMetadata: map[string]string{
 			"metricAggregationTimespan": "00:01", "metricAggregationType": "count", "metricId": "unittest/test", "targetValue": "10",
@@ -243,6 +254,30 @@ var azureAppInsightsScalerData = []azureAppInsightsScalerTestData{
 			"tenantId": "tenantId", "activeDirectoryClientId": "adClientId", "activeDirectoryClientPassword": "adClientPassword",
 		},
 	}},
+	{name: "app insights info from metadata and auth params", isError: false, config: scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"targetValue": "11", "activationTargetValue": "2", "metricId": "unittest/test", "metricAggregationTimespan": "01:02", "metricAggregationType": "max",
+			"metricFilter": "cloud/roleName eq 'test'", "cloud": "azureChinaCloud", "ignoreNullValues": "true",
+		},
+		AuthParams: map[string]string{
+			"applicationInsightsId": "1234", "tenantId": "4321", "activeDirectoryClientId": "5678", "activeDirectoryClientPassword": "pw",
+		},
+	}, parity: &metadataParity{fields: map[string]any{"TargetValue": 11, "ActivationTargetValue": 2, "IgnoreNullValues": true,
+		"azureAppInsightsInfo": azure.AppInsightsInfo{
+			ApplicationInsightsID: "1234", TenantID: "4321", MetricID: "unittest/test", AggregationTimespan: "01:02", AggregationType: "max",
+			Filter: "cloud/roleName eq 'test'", ClientID: "5678", ClientPassword: "pw",
+			AppInsightsResourceURL:  azure.AppInsightsResourceURLInCloud["AZURECHINACLOUD"],
+			ActiveDirectoryEndpoint: "https://login.chinacloudapi.cn/",
+		}}}},
+	{name: "pod identity ignores client credentials and target value not required as metric source", isError: false, config: scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"applicationInsightsId": "1234", "tenantId": "4321", "metricId": "unittest/test", "metricAggregationTimespan": "01:02", "metricAggregationType": "max",
+			"activeDirectoryClientId": "5678",
+		},
+		PodIdentity:    kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload},
+		AsMetricSource: true,
+	}, parity: &metadataParity{fields: map[string]any{"azureAppInsightsInfo.ClientID": "", "IgnoreNullValues": false,
+		"azureAppInsightsInfo.AppInsightsResourceURL": azure.DefaultAppInsightsResourceURL}}},
 }
 
 func TestNewAzureAppInsightsScaler(t *testing.T) {
@@ -264,6 +299,7 @@ func TestAzureAppInsightsGetMetricSpecForScaling(t *testing.T) {
 		if !testData.isError {
 			testData.config.TriggerIndex = triggerIndex
 			meta, err := parseAzureAppInsightsMetadata(&testData.config, logr.Discard())
+			checkMetadataParity(t, meta, err, testData.parity)
 			if err != nil {
 				t.Fatal("Could not parse metadata:", err)
 			}
@@ -282,3 +318,43 @@ func TestAzureAppInsightsGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+var azureAppInsightsMetadataErrors = []struct {
+	name     string
+	metadata map[string]string
+	err      string
+}{
+	{name: "missing target value", metadata: map[string]string{"metricAggregationTimespan": "01:02", "metricAggregationType": "max"},
+		err: "targetValue was not found in metadata"},
+	{name: "timespan with seconds", metadata: map[string]string{"targetValue": "1", "metricAggregationTimespan": "01:02:03", "metricAggregationType": "max"},
+		err: `metricAggregationTimespan not in the correct format. Should be hh:mm, e.g. 01:30, got "01:02:03"`},
+	{name: "timespan not a number", metadata: map[string]string{"targetValue": "1", "metricAggregationTimespan": "aa:02", "metricAggregationType": "max"},
+		err: `metricAggregationTimespan not in the correct format. Should be hh:mm, e.g. 01:30, got "aa:02"`},
+	{name: "unknown aggregation type", metadata: map[string]string{"targetValue": "1", "metricAggregationTimespan": "01:02", "metricAggregationType": "median"},
+		err: `field MetricAggregationType (param "metricAggregationType") invalid value "median", allowed: [avg count max min sum unique]`},
+	{name: "unknown cloud", metadata: map[string]string{"targetValue": "1", "metricAggregationTimespan": "01:02", "metricAggregationType": "max", "cloud": "azureGermanCloud"},
+		err: "there is no cloud environment matching the name azureGermanCloud"},
+	{name: "missing client id", metadata: map[string]string{"targetValue": "1", "metricAggregationTimespan": "01:02", "metricAggregationType": "max", "activeDirectoryClientId": ""},
+		err: "no activeDirectoryClientId given"},
+}
+
+func TestAzureAppInsightsParseMetadataErrors(t *testing.T) {
+	for _, testData := range azureAppInsightsMetadataErrors {
+		t.Run(testData.name, func(t *testing.T) {
+			metadata := map[string]string{"applicationInsightsId": "1234", "metricId": "unittest/test", "tenantId": "1234", "activeDirectoryClientId": "5678"}
+			for k, v := range testData.metadata {
+				metadata[k] = v
+			}
+			_, err := parseAzureAppInsightsMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: metadata,
+				AuthParams:      map[string]string{"activeDirectoryClientPassword": "secretpw"},
+			}, logr.Discard())
+			if err == nil || !strings.Contains(err.Error(), testData.err) {
+				t.Errorf("Expected error %q but got %v", testData.err, err)
+			}
+			if err != nil && strings.Contains(err.Error(), "secretpw") {
+				t.Errorf("Error leaks the client password: %v", err)
+			}
+		})
+	}
+}
@@ -19,7 +19,7 @@ package scalers
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"net/url"
 
 	"github.com/Azure/azure-kusto-go/kusto"
 	"github.com/go-logr/logr"
@@ -43,6 +43,40 @@ type azureDataExplorerScaler struct {
 
 const adxName = "azure-data-explorer"
 
+type azureDataExplorerMetadata struct {
+	// TenantID, ClientID and ClientSecret are only used without pod identity
+	TenantID            string  `keda:"name=tenantId,            order=authParams;triggerMetadata;resolvedEnv, optional"`
+	ClientID            string  `keda:"name=clientId,            order=authParams;triggerMetadata;resolvedEnv, optional"`
+	ClientSecret        string  `keda:"name=clientSecret,        order=authParams;resolvedEnv,                 optional"`
+	DatabaseName        string  `keda:"name=databaseName,        order=triggerMetadata;resolvedEnv"`
+	Endpoint            url.URL `keda:"name=endpoint,            order=triggerMetadata;resolvedEnv, schemes=https;http"`
+	Query               string  `keda:"name=query,               order=triggerMetadata;resolvedEnv"`
+	Threshold           float64 `keda:"name=threshold,           order=triggerMetadata, default=0, allowEmpty"`
+	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, default=0"`
+
+	podIdentity kedav1alpha1.AuthPodIdentity
+}
+
+func (m *azureDataExplorerMetadata) Validate() error {
+	switch m.podIdentity.Provider {
+	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		// the client credentials are required without pod identity and ignored with it
+		for _, param := range []struct{ name, value string }{
+			{"tenantId", m.TenantID},
+			{"clientId", m.ClientID},
+			{"clientSecret", m.ClientSecret},
+		} {
+			if param.value == "" {
+				return parameterNotFoundError(param.name)
+			}
+		}
+	default:
+		return fmt.Errorf("error parsing auth params")
+	}
+	return nil
+}
+
 func NewAzureDataExplorerScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -73,59 +107,33 @@ func NewAzureDataExplorerScaler(config *scalersconfig.ScalerConfig) (Scaler, err
 }
 
 func parseAzureDataExplorerMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*azure.DataExplorerMetadata, error) {
-	metadata, err := parseAzureDataExplorerAuthParams(config, logger)
-	if err != nil {
+	meta := azureDataExplorerMetadata{podIdentity: config.PodIdentity}
+	if err := config.TypedConfig(&meta); err != nil {
 		return nil, err
 	}
 
-	// Get database name.
-	databaseName, err := getParameterFromConfig(config, "databaseName", false)
-	if err != nil {
-		return nil, err
-	}
-	metadata.DatabaseName = databaseName
-
-	// Get endpoint.
-	endpoint, err := getParameterFromConfig(config, "endpoint", false)
-	if err != nil {
-		return nil, err
-	}
-	metadata.Endpoint = endpoint
-
-	// Get query.
-	query, err := getParameterFromConfig(config, "query", false)
+	activeDirectoryEndpoint, err := azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
 	if err != nil {
 		return nil, err
 	}
-	metadata.Query = query
-
-	// Get threshold.
-	if val, ok := config.TriggerMetadata["threshold"]; ok {
-		threshold, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing metadata. Details: can't parse threshold. Inner Error: %w", err)
-		}
-		metadata.Threshold = threshold
-	}
 
-	// Get activationThreshold.
-	metadata.ActivationThreshold = 0
-	if val, ok := config.TriggerMetadata["activationThreshold"]; ok {
-		activationThreshold, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing metadata. Details: can't parse activationThreshold. Inner Error: %w", err)
-		}
-		metadata.ActivationThreshold = activationThreshold
+	metadata := &azure.DataExplorerMetadata{
+		DatabaseName:            meta.DatabaseName,
+		Endpoint:                meta.Endpoint.String(),
+		Query:                   meta.Query,
+		Threshold:               meta.Threshold,
+		ActivationThreshold:     meta.ActivationThreshold,
+		ActiveDirectoryEndpoint: activeDirectoryEndpoint,
+		MetricName:              GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("%s-%s", adxName, meta.DatabaseName))),
 	}
-
-	// Generate metricName.
-	metadata.MetricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("%s-%s", adxName, metadata.DatabaseName)))
-
-	activeDirectoryEndpoint, err := azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
-	if err != nil {
-		return nil, err
+	switch meta.podIdentity.Provider {
+	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+		metadata.PodIdentity = meta.podIdentity
+	default:
+		metadata.TenantID = meta.TenantID
+		metadata.ClientID = meta.ClientID
+		metadata.ClientSecret = meta.ClientSecret
 	}
-	metadata.ActiveDirectoryEndpoint = activeDirectoryEndpoint
 
 	logger.V(1).Info("Parsed azureDataExplorerMetadata",
 		"database", metadata.DatabaseName,
@@ -139,43 +147,6 @@ func parseAzureDataExplorerMetadata(config *scalersconfig.ScalerConfig, logger l
 	return metadata, nil
 }
 
-func parseAzureDataExplorerAuthParams(config *scalersconfig.ScalerConfig, logger logr.Logger) (*azure.DataExplorerMetadata, error) {
-	metadata := azure.DataExplorerMetadata{}
-
-	switch config.PodIdentity.Provider {
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-		metadata.PodIdentity = config.PodIdentity
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		logger.V(1).Info("Pod Identity is not provided. Trying to resolve clientId, clientSecret and tenantId.")
-
-		tenantID, err := getParameterFromConfig(config, "tenantId", true)
-		if err != nil {
-			return nil, err
-		}
-		metadata.TenantID = tenantID
-
-		clientID, err := getParameterFromConfig(config, "clientId", true)
-		if err != nil {
-			return nil, err
-		}
-		metadata.ClientID = clientID
-
-		var clientSecret string
-		if val, ok := config.AuthParams["clientSecret"]; ok && val != "" {
-			clientSecret = val
-		} else if val, ok = config.TriggerMetadata["clientSecretFromEnv"]; ok && val != "" {
-			clientSecret = val
-		} else {
-			return nil, fmt.Errorf("error parsing metadata. Details: clientSecret was not found in metadata. Check your ScaledObject configuration")
-		}
-		metadata.ClientSecret = clientSecret
-
-	default:
-		return nil, fmt.Errorf("error parsing auth params")
-	}
-	return &metadata, nil
-}
-
 func (s azureDataExplorerScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	metricValue, err := azure.GetAzureDataExplorerMetricValue(ctx, s.client, s.metadata.DatabaseName, s.metadata.Query)
 	if err != nil {
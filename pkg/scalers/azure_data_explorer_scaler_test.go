@@ -19,6 +19,7 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -31,6 +32,7 @@ import (
 type parseDataExplorerMetadataTestData struct {
 	metadata map[string]string
 	isError  bool
+	parity   *metadataParity
 }
 
 type dataExplorerMetricIdentifier struct {
@@ -54,53 +56,60 @@ var dataExplorerResolvedEnv = map[string]string{
 	"tenantId":     azureTenantID,
 	"clientId":     aadAppClientID,
 	"clientSecret": aadAppSecret,
+	"ADX_SECRET":   aadAppSecret,
 }
 
 var testDataExplorerMetadataWithClientAndSecret = []parseDataExplorerMetadataTestData{
 	// Empty metadata - fail
-	{map[string]string{}, true},
+	{map[string]string{}, true, nil},
 	// Missing tenantId - fail
-	{map[string]string{"tenantId": "", "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true},
+	{map[string]string{"tenantId": "", "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true, nil},
 	// Missing clientId - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": "", "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": "", "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true, nil},
 	// Missing clientSecret - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true, nil},
 	// Missing endpoint - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": "", "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": "", "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true, nil},
 	// Missing databaseName - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": "", "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": "", "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true, nil},
 	// Missing query - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": "", "threshold": dataExplorerThreshold}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": "", "threshold": dataExplorerThreshold}, true, nil},
 	// Missing threshold - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": ""}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": ""}, true, nil},
 	// Invalid activationThreshold - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": "1", "activationThreshold": "A"}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": "1", "activationThreshold": "A"}, true, nil},
 	// known cloud
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold,
-		"cloud": "azureChinaCloud"}, false},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold,
+		"cloud": "azureChinaCloud"}, false, nil},
 	// private cloud
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold,
-		"cloud": "private", "activeDirectoryEndpoint": activeDirectoryEndpoint}, false},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold,
+		"cloud": "private", "activeDirectoryEndpoint": activeDirectoryEndpoint}, false, nil},
 	// private cloud - missing active directory endpoint - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold,
-		"cloud": "private"}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold,
+		"cloud": "private"}, true, nil},
+	// client credentials with the secret from the environment - pass
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": "2.5", "activationThreshold": "1",
+		"cloud": "Private", "activeDirectoryEndpoint": activeDirectoryEndpoint}, false, &metadataParity{fields: map[string]any{"TenantID": azureTenantID, "ClientID": aadAppClientID, "ClientSecret": aadAppSecret, "Threshold": 2.5, "ActivationThreshold": 1,
+		"ActiveDirectoryEndpoint": activeDirectoryEndpoint, "MetricName": "s0-azure-data-explorer-test_database"}}},
 	// All parameters set - pass
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, false},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, false, nil},
 	// False because we should not get clientSecret from TriggerMetadata
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecret": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecret": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true, nil},
 }
 
 var testDataExplorerMetadataWithPodIdentity = []parseDataExplorerMetadataTestData{
 	// Empty metadata - fail
-	{map[string]string{}, true},
+	{map[string]string{}, true, nil},
 	// Missing endpoint - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": "", "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": "", "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, true, nil},
 	// Missing query - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": "", "threshold": dataExplorerThreshold}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": "", "threshold": dataExplorerThreshold}, true, nil},
 	// Missing threshold - fail
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": ""}, true},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": ""}, true, nil},
+	// Client credentials ignored and threshold defaults to 0 - pass
+	{map[string]string{"tenantId": azureTenantID, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery}, false, &metadataParity{fields: map[string]any{"TenantID": "", "Threshold": 0}}},
 	// All parameters set - pass
-	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": aadAppSecret, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, false},
+	{map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "clientSecretFromEnv": "ADX_SECRET", "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": dataExplorerThreshold}, false, nil},
 }
 
 var testDataExplorerMetricIdentifiers = []dataExplorerMetricIdentifier{
@@ -111,7 +120,7 @@ var testDataExplorerMetricIdentifiers = []dataExplorerMetricIdentifier{
 func TestDataExplorerParseMetadata(t *testing.T) {
 	// Auth through clientId, clientSecret and tenantId
 	for id, testData := range testDataExplorerMetadataWithClientAndSecret {
-		_, err := parseAzureDataExplorerMetadata(
+		meta, err := parseAzureDataExplorerMetadata(
 			&scalersconfig.ScalerConfig{
 				ResolvedEnv:     dataExplorerResolvedEnv,
 				TriggerMetadata: testData.metadata,
@@ -125,11 +134,12 @@ func TestDataExplorerParseMetadata(t *testing.T) {
 		if testData.isError && err == nil {
 			t.Errorf("Test case %d: expected error but got success", id)
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
 	}
 
 	// Auth through Pod Identity
 	for _, testData := range testDataExplorerMetadataWithPodIdentity {
-		_, err := parseAzureDataExplorerMetadata(
+		meta, err := parseAzureDataExplorerMetadata(
 			&scalersconfig.ScalerConfig{
 				ResolvedEnv:     dataExplorerResolvedEnv,
 				TriggerMetadata: testData.metadata,
@@ -142,11 +152,12 @@ func TestDataExplorerParseMetadata(t *testing.T) {
 		if testData.isError && err == nil {
 			t.Error("Expected error but got success")
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
 	}
 
 	// Auth through Workload Identity
 	for _, testData := range testDataExplorerMetadataWithPodIdentity {
-		_, err := parseAzureDataExplorerMetadata(
+		meta, err := parseAzureDataExplorerMetadata(
 			&scalersconfig.ScalerConfig{
 				ResolvedEnv:     dataExplorerResolvedEnv,
 				TriggerMetadata: testData.metadata,
@@ -159,6 +170,7 @@ func TestDataExplorerParseMetadata(t *testing.T) {
 		if testData.isError && err == nil {
 			t.Error("Expected error but got success")
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
 	}
 }
 
@@ -191,3 +203,46 @@ func TestDataExplorerGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+type parseDataExplorerMetadataErrorTestData struct {
+	name        string
+	metadata    map[string]string
+	authParams  map[string]string
+	podIdentity kedav1alpha1.PodIdentityProvider
+	err         string
+}
+
+var testDataExplorerMetadataErrors = []parseDataExplorerMetadataErrorTestData{
+	{"missing tenantId", map[string]string{"clientId": aadAppClientID, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": "1"},
+		map[string]string{"clientSecret": aadAppSecret}, "", "error parsing metadata. Details: tenantId was not found in metadata. Check your ScaledObject configuration"},
+	{"missing clientId", map[string]string{"tenantId": azureTenantID, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": "1"},
+		map[string]string{"clientSecret": aadAppSecret}, "", "error parsing metadata. Details: clientId was not found in metadata. Check your ScaledObject configuration"},
+	{"missing clientSecret", map[string]string{"tenantId": azureTenantID, "clientId": aadAppClientID, "endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": "1"},
+		nil, "", "error parsing metadata. Details: clientSecret was not found in metadata. Check your ScaledObject configuration"},
+	{"unsupported pod identity", map[string]string{"endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": "1"},
+		nil, kedav1alpha1.PodIdentityProviderAws, "error parsing auth params"},
+	{"empty threshold", map[string]string{"endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": ""},
+		nil, kedav1alpha1.PodIdentityProviderAzureWorkload, `unable to set field Threshold (param "threshold") value "": expected float value`},
+	{"relative endpoint", map[string]string{"endpoint": "test-keda-e2e.eastus.kusto.windows.net", "databaseName": databaseName, "query": dataExplorerQuery, "threshold": "1"},
+		nil, kedav1alpha1.PodIdentityProviderAzureWorkload, `unable to set field Endpoint (param "endpoint") value "test-keda-e2e.eastus.kusto.windows.net": expected absolute URL with scheme and host`},
+	{"unsupported endpoint scheme", map[string]string{"endpoint": "ftp://test-keda-e2e.eastus.kusto.windows.net", "databaseName": databaseName, "query": dataExplorerQuery, "threshold": "1"},
+		nil, kedav1alpha1.PodIdentityProviderAzureWorkload, `unable to set field Endpoint (param "endpoint") value "ftp://test-keda-e2e.eastus.kusto.windows.net": unsupported URL scheme "ftp", has to be one of [https http]`},
+	{"private cloud without active directory endpoint", map[string]string{"endpoint": dataExplorerEndpoint, "databaseName": databaseName, "query": dataExplorerQuery, "threshold": "1", "cloud": "Private"},
+		nil, kedav1alpha1.PodIdentityProviderAzureWorkload, "activeDirectoryEndpoint must be provided for Private cloud type"},
+}
+
+func TestDataExplorerParseMetadataErrors(t *testing.T) {
+	for _, testData := range testDataExplorerMetadataErrors {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseAzureDataExplorerMetadata(
+				&scalersconfig.ScalerConfig{
+					TriggerMetadata: testData.metadata,
+					AuthParams:      testData.authParams,
+					PodIdentity:     kedav1alpha1.AuthPodIdentity{Provider: testData.podIdentity}},
+				logr.Discard())
+			if err == nil || !strings.Contains(err.Error(), testData.err) {
+				t.Errorf("Expected error %q but got %v", testData.err, err)
+			}
+		})
+	}
+}
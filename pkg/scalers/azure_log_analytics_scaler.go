@@ -26,7 +26,6 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -43,9 +42,8 @@ import (
 )
 
 const (
-	aadTokenEndpoint               = "%s/%s/oauth2/token"
-	laQueryEndpoint                = "%s/v1/workspaces/%s/query"
-	defaultLogAnalyticsResourceURL = "https://api.loganalytics.io"
+	aadTokenEndpoint = "%s/%s/oauth2/token"
+	laQueryEndpoint  = "%s/v1/workspaces/%s/query"
 )
 
 type azureLogAnalyticsScaler struct {
@@ -58,18 +56,62 @@ type azureLogAnalyticsScaler struct {
 }
 
 type azureLogAnalyticsMetadata struct {
-	tenantID                string
-	clientID                string
-	clientSecret            string
-	workspaceID             string
+	// TenantID, ClientID and ClientSecret are only used without pod identity
+	TenantID                string   `keda:"name=tenantId,                order=authParams;triggerMetadata;resolvedEnv, optional"`
+	ClientID                string   `keda:"name=clientId,                order=authParams;triggerMetadata;resolvedEnv, optional"`
+	ClientSecret            string   `keda:"name=clientSecret,            order=authParams;triggerMetadata;resolvedEnv, optional, sensitive"`
+	WorkspaceID             string   `keda:"name=workspaceId,             order=authParams;triggerMetadata;resolvedEnv"`
+	Query                   string   `keda:"name=query,                   order=triggerMetadata;resolvedEnv"`
+	Threshold               *float64 `keda:"name=threshold,               order=triggerMetadata;resolvedEnv, optional"`
+	ActivationThreshold     float64  `keda:"name=activationThreshold,     order=triggerMetadata;resolvedEnv, default=0"`
+	Cloud                   string   `keda:"name=cloud,                   order=triggerMetadata, default=azurePublicCloud"`
+	LogAnalyticsResourceURL string   `keda:"name=logAnalyticsResourceURL, order=triggerMetadata, optional"`
+	UnsafeSsl               bool     `keda:"name=unsafeSsl,               order=triggerMetadata;resolvedEnv, default=false"`
+
 	podIdentity             kedav1alpha1.AuthPodIdentity
-	query                   string
-	threshold               float64
-	activationThreshold     float64
-	triggerIndex            int
-	logAnalyticsResourceURL string
 	activeDirectoryEndpoint string
-	unsafeSsl               bool
+	asMetricSource          bool
+	triggerIndex            int
+}
+
+func (m *azureLogAnalyticsMetadata) Validate() error {
+	switch m.podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		// the client credentials are required without pod identity and ignored with it
+		for _, param := range []struct{ name, value string }{
+			{"tenantId", m.TenantID},
+			{"clientId", m.ClientID},
+			{"clientSecret", m.ClientSecret},
+		} {
+			if param.value == "" {
+				return parameterNotFoundError(param.name)
+			}
+		}
+	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+	default:
+		return fmt.Errorf("error parsing metadata. Details: Log Analytics Scaler doesn't support pod identity %s", m.podIdentity.Provider)
+	}
+
+	if m.Threshold == nil && !m.asMetricSource {
+		return parameterNotFoundError("threshold")
+	}
+
+	if strings.EqualFold(m.Cloud, azure.PrivateCloud) {
+		if m.LogAnalyticsResourceURL == "" {
+			return fmt.Errorf("logAnalyticsResourceURL must be provided for %s cloud type", azure.PrivateCloud)
+		}
+	} else if _, ok := logAnalyticsResourceURLInCloud[strings.ToUpper(m.Cloud)]; !ok {
+		return fmt.Errorf("there is no cloud environment matching the name %s", m.Cloud)
+	}
+	return nil
+}
+
+// targetValue returns the threshold, it's only unset when the scaler is used as a metric source
+func (m *azureLogAnalyticsMetadata) targetValue() float64 {
+	if m.Threshold == nil {
+		return 0
+	}
+	return *m.Threshold
 }
 
 type tokenData struct {
@@ -122,7 +164,7 @@ func NewAzureLogAnalyticsScaler(config *scalersconfig.ScalerConfig) (Scaler, err
 		return nil, fmt.Errorf("failed to initialize Log Analytics scaler. Scaled object: %s. Namespace: %s. Inner Error: %w", config.ScalableObjectName, config.ScalableObjectNamespace, err)
 	}
 
-	useSsl := azureLogAnalyticsMetadata.unsafeSsl
+	useSsl := azureLogAnalyticsMetadata.UnsafeSsl
 
 	return &azureLogAnalyticsScaler{
 		metricType: metricType,
@@ -135,91 +177,16 @@ func NewAzureLogAnalyticsScaler(config *scalersconfig.ScalerConfig) (Scaler, err
 }
 
 func parseAzureLogAnalyticsMetadata(config *scalersconfig.ScalerConfig) (*azureLogAnalyticsMetadata, error) {
-	meta := azureLogAnalyticsMetadata{}
-	switch config.PodIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		// Getting tenantId
-		tenantID, err := getParameterFromConfig(config, "tenantId", true)
-		if err != nil {
-			return nil, err
-		}
-		meta.tenantID = tenantID
-
-		// Getting clientId
-		clientID, err := getParameterFromConfig(config, "clientId", true)
-		if err != nil {
-			return nil, err
-		}
-		meta.clientID = clientID
-
-		// Getting clientSecret
-		clientSecret, err := getParameterFromConfig(config, "clientSecret", true)
-		if err != nil {
-			return nil, err
-		}
-		meta.clientSecret = clientSecret
-
-		meta.podIdentity = config.PodIdentity
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-		meta.podIdentity = config.PodIdentity
-	default:
-		return nil, fmt.Errorf("error parsing metadata. Details: Log Analytics Scaler doesn't support pod identity %s", config.PodIdentity.Provider)
-	}
-
-	// Getting workspaceId
-	workspaceID, err := getParameterFromConfig(config, "workspaceId", true)
-	if err != nil {
-		return nil, err
+	meta := azureLogAnalyticsMetadata{
+		podIdentity:    config.PodIdentity,
+		asMetricSource: config.AsMetricSource,
 	}
-	meta.workspaceID = workspaceID
-
-	// Getting query, observe that we dont check AuthParams for query
-	query, err := getParameterFromConfig(config, "query", false)
-	if err != nil {
+	if err := config.TypedConfig(&meta); err != nil {
 		return nil, err
 	}
-	meta.query = query
-
-	// Getting threshold, observe that we don't check AuthParams for threshold
-	val, err := getParameterFromConfig(config, "threshold", false)
-	if err != nil {
-		if config.AsMetricSource {
-			val = "0"
-		} else {
-			return nil, err
-		}
-	}
-	threshold, err := strconv.ParseFloat(val, 64)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing metadata. Details: can't parse threshold. Inner Error: %w", err)
-	}
-	meta.threshold = threshold
-
-	// Getting activationThreshold
-	meta.activationThreshold = 0
-	val, err = getParameterFromConfig(config, "activationThreshold", false)
-	if err == nil {
-		activationThreshold, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing metadata. Details: can't parse threshold. Inner Error: %w", err)
-		}
-		meta.activationThreshold = activationThreshold
-	}
-	meta.triggerIndex = config.TriggerIndex
-
-	meta.logAnalyticsResourceURL = defaultLogAnalyticsResourceURL
-	if cloud, ok := config.TriggerMetadata["cloud"]; ok {
-		if strings.EqualFold(cloud, azure.PrivateCloud) {
-			if resource, ok := config.TriggerMetadata["logAnalyticsResourceURL"]; ok && resource != "" {
-				meta.logAnalyticsResourceURL = resource
-			} else {
-				return nil, fmt.Errorf("logAnalyticsResourceURL must be provided for %s cloud type", azure.PrivateCloud)
-			}
-		} else if resource, ok := logAnalyticsResourceURLInCloud[strings.ToUpper(cloud)]; ok {
-			meta.logAnalyticsResourceURL = resource
-		} else {
-			return nil, fmt.Errorf("there is no cloud environment matching the name %s", cloud)
-		}
+	// the resource URL is only configurable for the private cloud
+	if !strings.EqualFold(meta.Cloud, azure.PrivateCloud) {
+		meta.LogAnalyticsResourceURL = logAnalyticsResourceURLInCloud[strings.ToUpper(meta.Cloud)]
 	}
 
 	activeDirectoryEndpoint, err := azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
@@ -227,18 +194,7 @@ func parseAzureLogAnalyticsMetadata(config *scalersconfig.ScalerConfig) (*azureL
 		return nil, err
 	}
 	meta.activeDirectoryEndpoint = activeDirectoryEndpoint
-
-	// Getting unsafeSsl, observe that we don't check AuthParams for unsafeSsl
-	meta.unsafeSsl = false
-	unsafeSslVal, err := getParameterFromConfig(config, "unsafeSsl", false)
-	if err == nil {
-		unsafeSsl, err := strconv.ParseBool(unsafeSslVal)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing metadata. Details: can't parse unsafeSsl. Inner Error: %w", err)
-		}
-		meta.unsafeSsl = unsafeSsl
-	}
-
+	meta.triggerIndex = config.TriggerIndex
 	return &meta, nil
 }
 
@@ -252,15 +208,20 @@ func getParameterFromConfig(config *scalersconfig.ScalerConfig, parameter string
 	} else if val, ok := config.TriggerMetadata[fmt.Sprintf("%sFromEnv", parameter)]; ok && val != "" {
 		return config.ResolvedEnv[config.TriggerMetadata[fmt.Sprintf("%sFromEnv", parameter)]], nil
 	}
-	return "", fmt.Errorf("error parsing metadata. Details: %s was not found in metadata. Check your ScaledObject configuration", parameter)
+	return "", parameterNotFoundError(parameter)
+}
+
+// parameterNotFoundError returns the error of the Azure scalers for a missing parameter
+func parameterNotFoundError(parameter string) error {
+	return fmt.Errorf("error parsing metadata. Details: %s was not found in metadata. Check your ScaledObject configuration", parameter)
 }
 
 func (s *azureLogAnalyticsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("%s-%s", "azure-log-analytics", s.metadata.workspaceID))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("%s-%s", "azure-log-analytics", s.metadata.WorkspaceID))),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.threshold),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue()),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -276,7 +237,7 @@ func (s *azureLogAnalyticsScaler) GetMetricsAndActivity(ctx context.Context, met
 
 	metric := GenerateMetricInMili(metricName, receivedMetric.value)
 
-	return []external_metrics.ExternalMetricValue{metric}, receivedMetric.value > s.metadata.activationThreshold, nil
+	return []external_metrics.ExternalMetricValue{metric}, receivedMetric.value > s.metadata.ActivationThreshold, nil
 }
 
 func (s *azureLogAnalyticsScaler) Close(context.Context) error {
@@ -292,7 +253,7 @@ func (s *azureLogAnalyticsScaler) getMetricData(ctx context.Context) (metricsDat
 		return metricsData{}, err
 	}
 
-	metricsInfo, err := s.executeQuery(ctx, s.metadata.query, tokenInfo)
+	metricsInfo, err := s.executeQuery(ctx, s.metadata.Query, tokenInfo)
 	if err != nil {
 		return metricsData{}, err
 	}
@@ -309,7 +270,7 @@ func (s *azureLogAnalyticsScaler) getAccessToken(ctx context.Context) (tokenData
 
 	switch s.metadata.podIdentity.Provider {
 	case "", kedav1alpha1.PodIdentityProviderNone:
-		tokenInfo, _ = getTokenFromCache(s.metadata.clientID, s.metadata.clientSecret)
+		tokenInfo, _ = getTokenFromCache(s.metadata.ClientID, s.metadata.ClientSecret)
 	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
 		tokenInfo, _ = getTokenFromCache(string(s.metadata.podIdentity.Provider), string(s.metadata.podIdentity.Provider))
 	}
@@ -322,8 +283,8 @@ func (s *azureLogAnalyticsScaler) getAccessToken(ctx context.Context) (tokenData
 
 		switch s.metadata.podIdentity.Provider {
 		case "", kedav1alpha1.PodIdentityProviderNone:
-			s.logger.V(1).Info("Token for Service Principal has been refreshed", "clientID", s.metadata.clientID, "scaler name", s.name, "namespace", s.namespace)
-			_ = setTokenInCache(s.metadata.clientID, s.metadata.clientSecret, newTokenInfo)
+			s.logger.V(1).Info("Token for Service Principal has been refreshed", "clientID", s.metadata.ClientID, "scaler name", s.name, "namespace", s.namespace)
+			_ = setTokenInCache(s.metadata.ClientID, s.metadata.ClientSecret, newTokenInfo)
 		case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
 			s.logger.V(1).Info("Token for Pod Identity has been refreshed", "type", s.metadata.podIdentity, "scaler name", s.name, "namespace", s.namespace)
 			_ = setTokenInCache(string(s.metadata.podIdentity.Provider), string(s.metadata.podIdentity.Provider), newTokenInfo)
@@ -351,8 +312,8 @@ func (s *azureLogAnalyticsScaler) executeQuery(ctx context.Context, query string
 
 		switch s.metadata.podIdentity.Provider {
 		case "", kedav1alpha1.PodIdentityProviderNone:
-			s.logger.V(1).Info("Token for Service Principal has been refreshed", "clientID", s.metadata.clientID, "scaler name", s.name, "namespace", s.namespace)
-			_ = setTokenInCache(s.metadata.clientID, s.metadata.clientSecret, tokenInfo)
+			s.logger.V(1).Info("Token for Service Principal has been refreshed", "clientID", s.metadata.ClientID, "scaler name", s.name, "namespace", s.namespace)
+			_ = setTokenInCache(s.metadata.ClientID, s.metadata.ClientSecret, tokenInfo)
 		case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
 			s.logger.V(1).Info("Token for Pod Identity has been refreshed", "type", s.metadata.podIdentity, "scaler name", s.name, "namespace", s.namespace)
 			_ = setTokenInCache(string(s.metadata.podIdentity.Provider), string(s.metadata.podIdentity.Provider), tokenInfo)
@@ -384,7 +345,7 @@ func (s *azureLogAnalyticsScaler) executeQuery(ctx context.Context, query string
 
 	if statusCode == 200 {
 		metricsInfo := metricsData{}
-		metricsInfo.threshold = s.metadata.threshold
+		metricsInfo.threshold = s.metadata.targetValue()
 		metricsInfo.value = 0
 
 		// Pre-validation of query result:
@@ -477,7 +438,7 @@ func (s *azureLogAnalyticsScaler) getAuthorizationToken(ctx context.Context) (to
 
 	switch s.metadata.podIdentity.Provider {
 	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		aadToken, err := azure.GetAzureADWorkloadIdentityToken(ctx, s.metadata.podIdentity.GetIdentityID(), s.metadata.podIdentity.GetIdentityTenantID(), s.metadata.podIdentity.GetIdentityAuthorityHost(), s.metadata.logAnalyticsResourceURL)
+		aadToken, err := azure.GetAzureADWorkloadIdentityToken(ctx, s.metadata.podIdentity.GetIdentityID(), s.metadata.podIdentity.GetIdentityTenantID(), s.metadata.podIdentity.GetIdentityAuthorityHost(), s.metadata.LogAnalyticsResourceURL)
 		if err != nil {
 			return tokenData{}, nil
 		}
@@ -491,7 +452,7 @@ func (s *azureLogAnalyticsScaler) getAuthorizationToken(ctx context.Context) (to
 			TokenType:               string(auth.CBSTokenTypeJWT),
 			AccessToken:             aadToken.AccessToken,
 			ExpiresOn:               expiresOn,
-			Resource:                s.metadata.logAnalyticsResourceURL,
+			Resource:                s.metadata.LogAnalyticsResourceURL,
 			IsWorkloadIdentityToken: true,
 		}
 
@@ -528,7 +489,7 @@ func (s *azureLogAnalyticsScaler) executeLogAnalyticsREST(ctx context.Context, q
 		return nil, 0, fmt.Errorf("can't construct JSON for request to Log Analytics API. Inner Error: %w", err)
 	}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(laQueryEndpoint, s.metadata.logAnalyticsResourceURL, s.metadata.workspaceID), bytes.NewBuffer(jsonBytes)) // URL-encoded payload
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(laQueryEndpoint, s.metadata.LogAnalyticsResourceURL, s.metadata.WorkspaceID), bytes.NewBuffer(jsonBytes)) // URL-encoded payload
 	if err != nil {
 		return nil, 0, fmt.Errorf("can't construct HTTP request to Log Analytics API. Inner Error: %w", err)
 	}
@@ -543,13 +504,13 @@ func (s *azureLogAnalyticsScaler) executeLogAnalyticsREST(ctx context.Context, q
 func (s *azureLogAnalyticsScaler) executeAADApicall(ctx context.Context) ([]byte, int, error) {
 	data := url.Values{
 		"grant_type":    {"client_credentials"},
-		"client_id":     {s.metadata.clientID},
+		"client_id":     {s.metadata.ClientID},
 		"redirect_uri":  {"http://"},
-		"resource":      {s.metadata.logAnalyticsResourceURL},
-		"client_secret": {s.metadata.clientSecret},
+		"resource":      {s.metadata.LogAnalyticsResourceURL},
+		"client_secret": {s.metadata.ClientSecret},
 	}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(aadTokenEndpoint, s.metadata.activeDirectoryEndpoint, s.metadata.tenantID), strings.NewReader(data.Encode())) // URL-encoded payload
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(aadTokenEndpoint, s.metadata.activeDirectoryEndpoint, s.metadata.TenantID), strings.NewReader(data.Encode())) // URL-encoded payload
 	if err != nil {
 		return nil, 0, fmt.Errorf("can't construct HTTP request to Azure Active Directory. Inner Error: %w", err)
 	}
@@ -563,9 +524,9 @@ func (s *azureLogAnalyticsScaler) executeAADApicall(ctx context.Context) ([]byte
 func (s *azureLogAnalyticsScaler) executeIMDSApicall(ctx context.Context) ([]byte, int, error) {
 	var urlStr string
 	if s.metadata.podIdentity.GetIdentityID() == "" {
-		urlStr = fmt.Sprintf(azure.MSIURL, s.metadata.logAnalyticsResourceURL)
+		urlStr = fmt.Sprintf(azure.MSIURL, s.metadata.LogAnalyticsResourceURL)
 	} else {
-		urlStr = fmt.Sprintf(azure.MSIURLWithClientID, s.metadata.logAnalyticsResourceURL, url.QueryEscape(s.metadata.podIdentity.GetIdentityID()))
+		urlStr = fmt.Sprintf(azure.MSIURLWithClientID, s.metadata.LogAnalyticsResourceURL, url.QueryEscape(s.metadata.podIdentity.GetIdentityID()))
 	}
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
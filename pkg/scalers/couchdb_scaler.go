@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"net/url"
 	"strconv"
+	"strings"
 
 	couchdb "github.com/go-kivik/couchdb/v3"
 	"github.com/go-kivik/kivik/v3"
@@ -30,16 +32,79 @@ type couchDBQueryRequest struct {
 }
 
 type couchDBMetadata struct {
-	connectionString     string
-	host                 string
-	port                 string
-	username             string
-	password             string
-	dbName               string
-	query                string
-	queryValue           int64
-	activationQueryValue int64
-	triggerIndex         int
+	// ConnectionString can't be combined with the Host, Port, Username and Password
+	ConnectionString     string `keda:"name=connectionString,     order=authParams;resolvedEnv,     optional"`
+	Host                 string `keda:"name=host,                 order=authParams;triggerMetadata, optional"`
+	Port                 int    `keda:"name=port,                 order=authParams;triggerMetadata, optional, min=1, max=65535"`
+	Username             string `keda:"name=username,             order=authParams;triggerMetadata, optional"`
+	Password             string `keda:"name=password,             order=authParams;resolvedEnv,     optional"`
+	DBName               string `keda:"name=dbName,               order=authParams;triggerMetadata"`
+	Query                string `keda:"name=query,                order=triggerMetadata"`
+	QueryValue           *int64 `keda:"name=queryValue,           order=triggerMetadata, optional"`
+	ActivationQueryValue int64  `keda:"name=activationQueryValue, order=triggerMetadata, default=0"`
+
+	asMetricSource bool
+	triggerIndex   int
+}
+
+func (m *couchDBMetadata) Validate() error {
+	if m.QueryValue == nil && !m.asMetricSource {
+		return fmt.Errorf("no queryValue given")
+	}
+	var request couchDBQueryRequest
+	if err := json.Unmarshal([]byte(m.Query), &request); err != nil {
+		return fmt.Errorf("query must be a valid mango query: %w", err)
+	}
+
+	if m.ConnectionString != "" {
+		if m.Host != "" || m.Port != 0 || m.Username != "" || m.Password != "" {
+			return fmt.Errorf("connectionString can't be combined with host, port, username or password")
+		}
+		return nil
+	}
+	if m.Host == "" {
+		return fmt.Errorf("no host given")
+	}
+	if strings.ContainsAny(m.Host, "/?#@ ") {
+		return fmt.Errorf("host must not contain any of the characters '/?#@ '")
+	}
+	if m.Port == 0 {
+		return fmt.Errorf("no port given")
+	}
+	if m.Username == "" {
+		return fmt.Errorf("no username given")
+	}
+	if m.Password == "" {
+		return fmt.Errorf("no password given")
+	}
+	return nil
+}
+
+// targetValue returns the queryValue, it's only unset when the scaler is used as a metric source
+func (m *couchDBMetadata) targetValue() int64 {
+	if m.QueryValue == nil {
+		return 0
+	}
+	return *m.QueryValue
+}
+
+// connectionURL returns the connectionString or the URL built from the host and port
+func (m *couchDBMetadata) connectionURL() string {
+	if m.ConnectionString != "" {
+		return m.ConnectionString
+	}
+	u := url.URL{Scheme: "http", Host: net.JoinHostPort(m.Host, strconv.Itoa(m.Port))}
+	return u.String()
+}
+
+// redactCredentials replaces the password and the connectionString password in the error
+func (m *couchDBMetadata) redactCredentials(err error) error {
+	secrets := []string{m.Password}
+	if u, parseErr := url.Parse(m.ConnectionString); parseErr == nil && u.User != nil {
+		password, _ := u.User.Password()
+		secrets = append(secrets, password)
+	}
+	return redactSecrets(err, secrets...)
 }
 
 type Res struct {
@@ -51,9 +116,9 @@ type Res struct {
 func (s *couchDBScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("coucdb-%s", s.metadata.dbName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("coucdb-%s", s.metadata.DBName))),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.queryValue),
+		Target: GetMetricTarget(s.metricType, s.metadata.targetValue()),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -73,9 +138,9 @@ func (s couchDBScaler) Close(ctx context.Context) error {
 }
 
 func (s *couchDBScaler) getQueryResult(ctx context.Context) (int64, error) {
-	db := s.client.DB(ctx, s.metadata.dbName)
+	db := s.client.DB(ctx, s.metadata.DBName)
 	var request couchDBQueryRequest
-	err := json.Unmarshal([]byte(s.metadata.query), &request)
+	err := json.Unmarshal([]byte(s.metadata.Query), &request)
 	if err != nil {
 		s.logger.Error(err, fmt.Sprintf("Couldn't unmarshal query string because of %v", err))
 		return 0, err
@@ -98,90 +163,12 @@ func (s *couchDBScaler) getQueryResult(ctx context.Context) (int64, error) {
 }
 
 func parseCouchDBMetadata(config *scalersconfig.ScalerConfig) (*couchDBMetadata, string, error) {
-	var connStr string
-	var err error
-	meta := couchDBMetadata{}
-
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		meta.query = val
-	} else {
-		return nil, "", fmt.Errorf("no query given")
-	}
-
-	if val, ok := config.TriggerMetadata["queryValue"]; ok {
-		queryValue, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to convert %v to int, because of %w", val, err)
-		}
-		meta.queryValue = queryValue
-	} else {
-		if config.AsMetricSource {
-			meta.queryValue = 0
-		} else {
-			return nil, "", fmt.Errorf("no queryValue given")
-		}
-	}
-
-	meta.activationQueryValue = 0
-	if val, ok := config.TriggerMetadata["activationQueryValue"]; ok {
-		activationQueryValue, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to convert %v to int, because of %w", val, err)
-		}
-		meta.activationQueryValue = activationQueryValue
-	}
-
-	dbName, err := GetFromAuthOrMeta(config, "dbName")
-	if err != nil {
+	meta := couchDBMetadata{asMetricSource: config.AsMetricSource}
+	if err := config.TypedConfig(&meta); err != nil {
 		return nil, "", err
 	}
-	meta.dbName = dbName
-
-	switch {
-	case config.AuthParams["connectionString"] != "":
-		meta.connectionString = config.AuthParams["connectionString"]
-	case config.TriggerMetadata["connectionStringFromEnv"] != "":
-		meta.connectionString = config.ResolvedEnv[config.TriggerMetadata["connectionStringFromEnv"]]
-	default:
-		meta.connectionString = ""
-		host, err := GetFromAuthOrMeta(config, "host")
-		if err != nil {
-			return nil, "", err
-		}
-		meta.host = host
-
-		port, err := GetFromAuthOrMeta(config, "port")
-		if err != nil {
-			return nil, "", err
-		}
-		meta.port = port
-
-		username, err := GetFromAuthOrMeta(config, "username")
-		if err != nil {
-			return nil, "", err
-		}
-		meta.username = username
-
-		if config.AuthParams["password"] != "" {
-			meta.password = config.AuthParams["password"]
-		} else if config.TriggerMetadata["passwordFromEnv"] != "" {
-			meta.password = config.ResolvedEnv[config.TriggerMetadata["passwordFromEnv"]]
-		}
-		if len(meta.password) == 0 {
-			return nil, "", fmt.Errorf("no password given")
-		}
-	}
-
-	if meta.connectionString != "" {
-		connStr = meta.connectionString
-	} else {
-		// Build connection str
-		addr := net.JoinHostPort(meta.host, meta.port)
-		// nosemgrep: db-connection-string
-		connStr = "http://" + addr
-	}
 	meta.triggerIndex = config.TriggerIndex
-	return &meta, connStr, nil
+	return &meta, meta.connectionURL(), nil
 }
 
 func NewCouchDBScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
@@ -197,20 +184,20 @@ func NewCouchDBScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (
 
 	client, err := kivik.New("couch", connStr)
 	if err != nil {
-		return nil, fmt.Errorf("%w", err)
+		return nil, meta.redactCredentials(err)
 	}
 
-	err = client.Authenticate(ctx, couchdb.BasicAuth("admin", meta.password))
+	err = client.Authenticate(ctx, couchdb.BasicAuth("admin", meta.Password))
 	if err != nil {
-		return nil, err
+		return nil, meta.redactCredentials(err)
 	}
 
 	isconnected, err := client.Ping(ctx)
 	if !isconnected {
-		return nil, fmt.Errorf("%w", err)
+		return nil, fmt.Errorf("%w", meta.redactCredentials(err))
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to ping couchDB, because of %w", err)
+		return nil, fmt.Errorf("failed to ping couchDB, because of %w", meta.redactCredentials(err))
 	}
 
 	return &couchDBScaler{
@@ -230,5 +217,5 @@ func (s *couchDBScaler) GetMetricsAndActivity(ctx context.Context, metricName st
 
 	metric := GenerateMetricInMili(metricName, float64(result))
 
-	return append([]external_metrics.ExternalMetricValue{}, metric), result > s.metadata.activationQueryValue, nil
+	return append([]external_metrics.ExternalMetricValue{}, metric), result > s.metadata.ActivationQueryValue, nil
 }
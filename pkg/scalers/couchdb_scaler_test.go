@@ -2,6 +2,8 @@ package scalers
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	_ "github.com/go-kivik/couchdb/v3"
@@ -23,6 +25,13 @@ type parseCouchDBMetadataTestData struct {
 	raisesError bool
 }
 
+type parseCouchDBMetadataErrorTestData struct {
+	name          string
+	metadata      map[string]string
+	authParams    map[string]string
+	expectedError string
+}
+
 type couchDBMetricIdentifier struct {
 	metadataTestData *parseCouchDBMetadataTestData
 	triggerIndex     int
@@ -58,6 +67,20 @@ var testCOUCHDBMetadata = []parseCouchDBMetadataTestData{
 		resolvedEnv: testCouchDBResolvedEnv,
 		raisesError: false,
 	},
+	// password from env
+	{
+		metadata:    map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } } }`, "queryValue": "1", "passwordFromEnv": "CouchDB_PASSWORD"},
+		authParams:  map[string]string{"dbName": "animals", "host": "localhost", "port": "5984", "username": "admin"},
+		resolvedEnv: testCouchDBResolvedEnv,
+		raisesError: false,
+	},
+	// explicit zero queryValue
+	{
+		metadata:    map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } } }`, "queryValue": "0", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "animals"},
+		authParams:  map[string]string{},
+		resolvedEnv: testCouchDBResolvedEnv,
+		raisesError: false,
+	},
 	// wrong activationQueryValue
 	{
 		metadata:    map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } }, "fields": ["_id", "feet", "greeting"] }`, "queryValue": "1", "activationQueryValue": "1", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "animals"},
@@ -67,6 +90,45 @@ var testCOUCHDBMetadata = []parseCouchDBMetadataTestData{
 	},
 }
 
+var testCouchDBMetadataErrors = []parseCouchDBMetadataErrorTestData{
+	{
+		name:          "connectionString combined with host",
+		metadata:      map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } } }`, "queryValue": "1", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "animals", "host": "localhost"},
+		authParams:    map[string]string{},
+		expectedError: "connectionString can't be combined with host, port, username or password",
+	},
+	{
+		name:          "port out of range",
+		metadata:      map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } } }`, "queryValue": "1"},
+		authParams:    map[string]string{"dbName": "animals", "host": "localhost", "port": "65536", "username": "admin", "password": "YeFvQno9LylIm5MDgwcV"},
+		expectedError: `field Port (param "port") value 65536 is greater than the maximum 65535`,
+	},
+	{
+		name:          "host with path segment",
+		metadata:      map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } } }`, "queryValue": "1"},
+		authParams:    map[string]string{"dbName": "animals", "host": "localhost/_users", "port": "5984", "username": "admin", "password": "YeFvQno9LylIm5MDgwcV"},
+		expectedError: "host must not contain any of the characters '/?#@ '",
+	},
+	{
+		name:          "invalid query",
+		metadata:      map[string]string{"query": `{ "selector": `, "queryValue": "1", "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "animals"},
+		authParams:    map[string]string{},
+		expectedError: "query must be a valid mango query",
+	},
+	{
+		name:          "missing queryValue",
+		metadata:      map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } } }`, "connectionStringFromEnv": "CouchDB_CONN_STR", "dbName": "animals"},
+		authParams:    map[string]string{},
+		expectedError: "no queryValue given",
+	},
+	{
+		name:          "missing port",
+		metadata:      map[string]string{"query": `{ "selector": { "feet": { "$gt": 0 } } }`, "queryValue": "1"},
+		authParams:    map[string]string{"dbName": "animals", "host": "localhost", "username": "admin", "password": "YeFvQno9LylIm5MDgwcV"},
+		expectedError: "no port given",
+	},
+}
+
 var couchDBMetricIdentifiers = []couchDBMetricIdentifier{
 	{metadataTestData: &testCOUCHDBMetadata[2], triggerIndex: 0, name: "s0-coucdb-animals"},
 	{metadataTestData: &testCOUCHDBMetadata[2], triggerIndex: 1, name: "s1-coucdb-animals"},
@@ -74,13 +136,51 @@ var couchDBMetricIdentifiers = []couchDBMetricIdentifier{
 
 func TestParseCouchDBMetadata(t *testing.T) {
 	for _, testData := range testCOUCHDBMetadata {
-		_, _, err := parseCouchDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		_, _, err := parseCouchDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: testData.resolvedEnv})
 		if err != nil && !testData.raisesError {
 			t.Error("Expected success but got error:", err)
 		}
 	}
 }
 
+func TestParseCouchDBMetadataErrors(t *testing.T) {
+	for _, testData := range testCouchDBMetadataErrors {
+		t.Run(testData.name, func(t *testing.T) {
+			_, _, err := parseCouchDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: testCouchDBResolvedEnv})
+			if err == nil || !strings.Contains(err.Error(), testData.expectedError) {
+				t.Errorf("Expected error containing %q but got %v", testData.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCouchDBConnectionURL(t *testing.T) {
+	meta, connStr, err := parseCouchDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testCOUCHDBMetadata[3].metadata, AuthParams: testCOUCHDBMetadata[3].authParams})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if connStr != "http://localhost:5984" {
+		t.Errorf("Expected connection URL http://localhost:5984 but got %s", connStr)
+	}
+
+	err = meta.redactCredentials(errors.New("authentication failed for admin:YeFvQno9LylIm5MDgwcV"))
+	if strings.Contains(err.Error(), "YeFvQno9LylIm5MDgwcV") {
+		t.Errorf("Expected password to be redacted but got %v", err)
+	}
+
+	meta, connStr, err = parseCouchDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testCOUCHDBMetadata[1].metadata, ResolvedEnv: testCouchDBResolvedEnv})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if connStr != testCouchDBResolvedEnv["CouchDB_CONN_STR"] {
+		t.Errorf("Expected connection URL from connectionString but got %s", connStr)
+	}
+	err = meta.redactCredentials(errors.New("parse " + connStr + ": invalid"))
+	if strings.Contains(err.Error(), "YeFvQno9LylIm5MDgwcV") {
+		t.Errorf("Expected connectionString password to be redacted but got %v", err)
+	}
+}
+
 func TestCouchDBGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range couchDBMetricIdentifiers {
 		meta, _, err := parseCouchDBMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: testData.metadataTestData.resolvedEnv, AuthParams: testData.metadataTestData.authParams, TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -24,20 +23,45 @@ type datadogScaler struct {
 }
 
 type datadogMetadata struct {
-	apiKey                   string
-	appKey                   string
-	datadogSite              string
-	query                    string
-	queryValue               float64
-	queryAggegrator          string
-	activationQueryValue     float64
-	vType                    v2.MetricTargetType
-	metricName               string
-	age                      int
-	timeWindowOffset         int
-	lastAvailablePointOffset int
-	useFiller                bool
-	fillValue                float64
+	APIKey      string `keda:"name=apiKey,      order=authParams"`
+	AppKey      string `keda:"name=appKey,      order=authParams"`
+	DatadogSite string `keda:"name=datadogSite, order=authParams, default=datadoghq.com"`
+
+	Query                    string   `keda:"name=query,                    order=triggerMetadata"`
+	QueryValue               *float64 `keda:"name=queryValue,               order=triggerMetadata, optional"`
+	QueryAggregator          string   `keda:"name=queryAggregator,          order=triggerMetadata, optional, enum=average;max, ignoreCase"`
+	ActivationQueryValue     float64  `keda:"name=activationQueryValue,     order=triggerMetadata, default=0"`
+	Age                      int      `keda:"name=age,                      order=triggerMetadata, default=90, min=0"`
+	TimeWindowOffset         int      `keda:"name=timeWindowOffset,         order=triggerMetadata, default=0, min=0"`
+	LastAvailablePointOffset int      `keda:"name=lastAvailablePointOffset, order=triggerMetadata, default=0, min=0"`
+	FillValue                *float64 `keda:"name=metricUnavailableValue,   order=triggerMetadata, optional"`
+	Type                     string   `keda:"name=type,                     order=triggerMetadata, optional"`
+
+	vType          v2.MetricTargetType
+	metricName     string
+	asMetricSource bool
+}
+
+func (m *datadogMetadata) Validate() error {
+	if m.QueryValue == nil && !m.asMetricSource {
+		return fmt.Errorf("no queryValue given")
+	}
+	// the missing query is reported by the typed config
+	if m.Query == "" {
+		return nil
+	}
+	if _, err := parseDatadogQuery(m.Query); err != nil {
+		return fmt.Errorf("error in query: %w", err)
+	}
+	return nil
+}
+
+// targetValue returns the queryValue, it's only unset when the scaler is used as a metric source
+func (m *datadogMetadata) targetValue() float64 {
+	if m.QueryValue == nil {
+		return 0
+	}
+	return *m.QueryValue
 }
 
 const maxString = "max"
@@ -80,114 +104,21 @@ func parseDatadogQuery(q string) (bool, error) {
 }
 
 func parseDatadogMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*datadogMetadata, error) {
-	meta := datadogMetadata{}
-
-	if val, ok := config.TriggerMetadata["age"]; ok {
-		age, err := strconv.Atoi(val)
-		if err != nil {
-			return nil, fmt.Errorf("age parsing error %w", err)
-		}
-		meta.age = age
-
-		if age < 0 {
-			return nil, fmt.Errorf("age should not be smaller than 0 seconds")
-		}
-		if age < 60 {
-			logger.Info("selecting a window smaller than 60 seconds can cause Datadog not finding a metric value for the query")
-		}
-	} else {
-		meta.age = 90 // Default window 90 seconds
-	}
-
-	if val, ok := config.TriggerMetadata["timeWindowOffset"]; ok {
-		timeWindowOffset, err := strconv.Atoi(val)
-		if err != nil {
-			return nil, fmt.Errorf("timeWindowOffset parsing error %w", err)
-		}
-		if timeWindowOffset < 0 {
-			return nil, fmt.Errorf("timeWindowOffset should not be smaller than 0 seconds")
-		}
-		meta.timeWindowOffset = timeWindowOffset
-	} else {
-		meta.timeWindowOffset = 0 // Default delay 0 seconds
-	}
-
-	if val, ok := config.TriggerMetadata["lastAvailablePointOffset"]; ok {
-		lastAvailablePointOffset, err := strconv.Atoi(val)
-		if err != nil {
-			return nil, fmt.Errorf("lastAvailablePointOffset parsing error %w", err)
-		}
-
-		if lastAvailablePointOffset < 0 {
-			return nil, fmt.Errorf("lastAvailablePointOffset should not be smaller than 0")
-		}
-		meta.lastAvailablePointOffset = lastAvailablePointOffset
-	} else {
-		meta.lastAvailablePointOffset = 0 // Default use the last point
-	}
-
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		_, err := parseDatadogQuery(val)
-
-		if err != nil {
-			return nil, fmt.Errorf("error in query: %w", err)
-		}
-		meta.query = val
-	} else {
-		return nil, fmt.Errorf("no query given")
-	}
-
-	if val, ok := config.TriggerMetadata["queryValue"]; ok {
-		queryValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("queryValue parsing error %w", err)
-		}
-		meta.queryValue = queryValue
-	} else {
-		if config.AsMetricSource {
-			meta.queryValue = 0
-		} else {
-			return nil, fmt.Errorf("no queryValue given")
-		}
+	meta := &datadogMetadata{asMetricSource: config.AsMetricSource}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing datadog metadata: %w", err)
 	}
 
-	if val, ok := config.TriggerMetadata["queryAggregator"]; ok && val != "" {
-		queryAggregator := strings.ToLower(val)
-		switch queryAggregator {
-		case avgString, maxString:
-			meta.queryAggegrator = queryAggregator
-		default:
-			return nil, fmt.Errorf("queryAggregator value %s has to be one of '%s, %s'", queryAggregator, avgString, maxString)
-		}
-	} else {
-		meta.queryAggegrator = ""
+	if meta.Age < 60 {
+		logger.Info("selecting a window smaller than 60 seconds can cause Datadog not finding a metric value for the query")
 	}
 
-	meta.activationQueryValue = 0
-	if val, ok := config.TriggerMetadata["activationQueryValue"]; ok {
-		activationQueryValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("queryValue parsing error %w", err)
-		}
-		meta.activationQueryValue = activationQueryValue
-	}
-
-	if val, ok := config.TriggerMetadata["metricUnavailableValue"]; ok {
-		fillValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("metricUnavailableValue parsing error %w", err)
-		}
-		meta.fillValue = fillValue
-		meta.useFiller = true
-	}
-
-	if val, ok := config.TriggerMetadata["type"]; ok {
+	if meta.Type != "" {
 		logger.V(0).Info("trigger.metadata.type is deprecated in favor of trigger.metricType")
 		if config.MetricType != "" {
 			return nil, fmt.Errorf("only one of trigger.metadata.type or trigger.metricType should be defined")
 		}
-		val = strings.ToLower(val)
-		switch val {
+		switch strings.ToLower(meta.Type) {
 		case avgString:
 			meta.vType = v2.AverageValueMetricType
 		case "global":
@@ -203,30 +134,10 @@ func parseDatadogMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger
 		meta.vType = metricType
 	}
 
-	if val, ok := config.AuthParams["apiKey"]; ok {
-		meta.apiKey = val
-	} else {
-		return nil, fmt.Errorf("no api key given")
-	}
-
-	if val, ok := config.AuthParams["appKey"]; ok {
-		meta.appKey = val
-	} else {
-		return nil, fmt.Errorf("no app key given")
-	}
-
-	siteVal := "datadoghq.com"
-
-	if val, ok := config.AuthParams["datadogSite"]; ok && val != "" {
-		siteVal = val
-	}
-
-	meta.datadogSite = siteVal
-
-	metricName := meta.query[0:strings.Index(meta.query, "{")]
+	metricName := meta.Query[0:strings.Index(meta.Query, "{")]
 	meta.metricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("datadog-%s", metricName)))
 
-	return &meta, nil
+	return meta, nil
 }
 
 // newDatddogConnection tests a connection to the Datadog API
@@ -236,10 +147,10 @@ func newDatadogConnection(ctx context.Context, meta *datadogMetadata, config *sc
 		datadog.ContextAPIKeys,
 		map[string]datadog.APIKey{
 			"apiKeyAuth": {
-				Key: meta.apiKey,
+				Key: meta.APIKey,
 			},
 			"appKeyAuth": {
-				Key: meta.appKey,
+				Key: meta.AppKey,
 			},
 		},
 	)
@@ -247,7 +158,7 @@ func newDatadogConnection(ctx context.Context, meta *datadogMetadata, config *sc
 	ctx = context.WithValue(ctx,
 		datadog.ContextServerVariables,
 		map[string]string{
-			"site": meta.datadogSite,
+			"site": meta.DatadogSite,
 		})
 
 	configuration := datadog.NewConfiguration()
@@ -277,10 +188,10 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 		datadog.ContextAPIKeys,
 		map[string]datadog.APIKey{
 			"apiKeyAuth": {
-				Key: s.metadata.apiKey,
+				Key: s.metadata.APIKey,
 			},
 			"appKeyAuth": {
-				Key: s.metadata.appKey,
+				Key: s.metadata.AppKey,
 			},
 		},
 	)
@@ -288,12 +199,12 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 	ctx = context.WithValue(ctx,
 		datadog.ContextServerVariables,
 		map[string]string{
-			"site": s.metadata.datadogSite,
+			"site": s.metadata.DatadogSite,
 		})
 
-	timeWindowTo := time.Now().Unix() - int64(s.metadata.timeWindowOffset)
-	timeWindowFrom := timeWindowTo - int64(s.metadata.age)
-	resp, r, err := s.apiClient.MetricsApi.QueryMetrics(ctx, timeWindowFrom, timeWindowTo, s.metadata.query) //nolint:bodyclose
+	timeWindowTo := time.Now().Unix() - int64(s.metadata.TimeWindowOffset)
+	timeWindowFrom := timeWindowTo - int64(s.metadata.Age)
+	resp, r, err := s.apiClient.MetricsApi.QueryMetrics(ctx, timeWindowFrom, timeWindowTo, s.metadata.Query) //nolint:bodyclose
 
 	if r != nil {
 		if r.StatusCode == 429 {
@@ -326,14 +237,14 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 	series := resp.GetSeries()
 
 	if len(series) == 0 {
-		if !s.metadata.useFiller {
+		if s.metadata.FillValue == nil {
 			return 0, fmt.Errorf("no Datadog metrics returned for the given time window")
 		}
-		return s.metadata.fillValue, nil
+		return *s.metadata.FillValue, nil
 	}
 
 	// Require queryAggregator be set explicitly for multi-query
-	if len(series) > 1 && s.metadata.queryAggegrator == "" {
+	if len(series) > 1 && s.metadata.QueryAggregator == "" {
 		return 0, fmt.Errorf("query returned more than 1 series; modify the query to return only 1 series or add a queryAggregator")
 	}
 
@@ -349,22 +260,22 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 				break
 			}
 		}
-		if index < s.metadata.lastAvailablePointOffset {
+		if index < s.metadata.LastAvailablePointOffset {
 			return 0, fmt.Errorf("index is smaller than the lastAvailablePointOffset")
 		}
-		index -= s.metadata.lastAvailablePointOffset
+		index -= s.metadata.LastAvailablePointOffset
 
 		if len(points) == 0 || len(points[index]) < 2 || points[index][1] == nil {
-			if !s.metadata.useFiller {
+			if s.metadata.FillValue == nil {
 				return 0, fmt.Errorf("no Datadog metrics returned for the given time window")
 			}
-			return s.metadata.fillValue, nil
+			return *s.metadata.FillValue, nil
 		}
 		// Return the last point from the series
 		results[i] = *points[index][1]
 	}
 
-	switch s.metadata.queryAggegrator {
+	switch s.metadata.QueryAggregator {
 	case avgString:
 		return AvgFloatFromSlice(results), nil
 	default:
@@ -379,7 +290,7 @@ func (s *datadogScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec
 		Metric: v2.MetricIdentifier{
 			Name: s.metadata.metricName,
 		},
-		Target: GetMetricTargetMili(s.metadata.vType, s.metadata.queryValue),
+		Target: GetMetricTargetMili(s.metadata.vType, s.metadata.targetValue()),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -397,7 +308,7 @@ func (s *datadogScaler) GetMetricsAndActivity(ctx context.Context, metricName st
 
 	metric := GenerateMetricInMili(metricName, num)
 
-	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.activationQueryValue, nil
+	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.ActivationQueryValue, nil
 }
 
 // MaxFloatFromSlice finds the largest value in a slice of floats
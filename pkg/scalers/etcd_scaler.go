@@ -5,6 +5,8 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -19,15 +21,8 @@ import (
 )
 
 const (
-	endpoints                          = "endpoints"
-	watchKey                           = "watchKey"
-	value                              = "value"
-	activationValue                    = "activationValue"
-	watchProgressNotifyInterval        = "watchProgressNotifyInterval"
-	etcdMetricType                     = "External"
-	etcdTLSEnable                      = "enable"
-	etcdTLSDisable                     = "disable"
-	defaultWatchProgressNotifyInterval = 600
+	etcdMetricType = "External"
+	etcdTLSEnable  = "enable"
 )
 
 type etcdScaler struct {
@@ -38,18 +33,64 @@ type etcdScaler struct {
 }
 
 type etcdMetadata struct {
-	endpoints                   []string
-	watchKey                    string
-	value                       float64
-	activationValue             float64
-	watchProgressNotifyInterval int
-	triggerIndex                int
+	Endpoints                   []string `keda:"name=endpoints,                   order=triggerMetadata"`
+	WatchKey                    string   `keda:"name=watchKey,                    order=triggerMetadata"`
+	Value                       float64  `keda:"name=value,                       order=triggerMetadata"`
+	ActivationValue             float64  `keda:"name=activationValue,             order=triggerMetadata, default=0"`
+	WatchProgressNotifyInterval int      `keda:"name=watchProgressNotifyInterval, order=triggerMetadata, default=600, min=1"`
+
+	Username string `keda:"name=username, order=authParams, optional, requiredIf=Password"`
+	Password string `keda:"name=password, order=authParams, optional"`
+
 	// TLS
-	enableTLS   bool
-	cert        string
-	key         string
-	keyPassword string
-	ca          string
+	TLS         string `keda:"name=tls,         order=authParams, default=disable, enum=enable;disable"`
+	Cert        string `keda:"name=cert,        order=authParams, optional"`
+	Key         string `keda:"name=key,         order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+	CA          string `keda:"name=ca,          order=authParams, optional"`
+
+	enableTLS    bool
+	triggerIndex int
+}
+
+func (m *etcdMetadata) Validate() error {
+	for i, endpoint := range m.Endpoints {
+		if err := validateEtcdEndpoint(endpoint); err != nil {
+			return fmt.Errorf("endpoint %d: %w", i, err)
+		}
+	}
+	if m.Value <= 0 {
+		return fmt.Errorf("value must be a float greater than 0")
+	}
+	if m.TLS == etcdTLSEnable {
+		if m.Cert != "" && m.Key == "" {
+			return errors.New("key must be provided with cert")
+		}
+		if m.Key != "" && m.Cert == "" {
+			return errors.New("cert must be provided with key")
+		}
+	}
+	return nil
+}
+
+// validateEtcdEndpoint checks the endpoint is either host:port or a URL with host,
+// the error doesn't contain the endpoint as URLs may carry credentials
+func validateEtcdEndpoint(endpoint string) error {
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" {
+			return errors.New("expected host:port or URL with scheme and host")
+		}
+		return nil
+	}
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil || host == "" {
+		return errors.New("expected host:port or URL with scheme and host")
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("invalid port %q", port)
+	}
+	return nil
 }
 
 // NewEtcdScaler creates a new etcdScaler
@@ -76,77 +117,12 @@ func NewEtcdScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	}, nil
 }
 
-func parseEtcdAuthParams(config *scalersconfig.ScalerConfig, meta *etcdMetadata) error {
-	meta.enableTLS = false
-	if val, ok := config.AuthParams["tls"]; ok {
-		val = strings.TrimSpace(val)
-		if val == etcdTLSEnable {
-			certGiven := config.AuthParams["cert"] != ""
-			keyGiven := config.AuthParams["key"] != ""
-			if certGiven && !keyGiven {
-				return errors.New("key must be provided with cert")
-			}
-			if keyGiven && !certGiven {
-				return errors.New("cert must be provided with key")
-			}
-			meta.ca = config.AuthParams["ca"]
-			meta.cert = config.AuthParams["cert"]
-			meta.key = config.AuthParams["key"]
-			if value, found := config.AuthParams["keyPassword"]; found {
-				meta.keyPassword = value
-			} else {
-				meta.keyPassword = ""
-			}
-			meta.enableTLS = true
-		} else if val != etcdTLSDisable {
-			return fmt.Errorf("err incorrect value for TLS given: %s", val)
-		}
-	}
-
-	return nil
-}
-
 func parseEtcdMetadata(config *scalersconfig.ScalerConfig) (*etcdMetadata, error) {
 	meta := &etcdMetadata{}
-	var err error
-	meta.endpoints = strings.Split(config.TriggerMetadata[endpoints], ",")
-	if len(meta.endpoints) == 0 || meta.endpoints[0] == "" {
-		return nil, fmt.Errorf("endpoints required")
-	}
-
-	meta.watchKey = config.TriggerMetadata[watchKey]
-	if len(meta.watchKey) == 0 {
-		return nil, fmt.Errorf("watchKey required")
-	}
-
-	value, err := strconv.ParseFloat(config.TriggerMetadata[value], 64)
-	if err != nil || value <= 0 {
-		return nil, fmt.Errorf("value must be a float greater than 0")
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing etcd metadata: %w", err)
 	}
-	meta.value = value
-
-	meta.activationValue = 0
-	if val, ok := config.TriggerMetadata[activationValue]; ok {
-		activationValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("activationValue must be a float")
-		}
-		meta.activationValue = activationValue
-	}
-
-	meta.watchProgressNotifyInterval = defaultWatchProgressNotifyInterval
-	if val, ok := config.TriggerMetadata[watchProgressNotifyInterval]; ok {
-		interval, err := strconv.Atoi(val)
-		if err != nil || interval <= 0 {
-			return nil, fmt.Errorf("watchProgressNotifyInterval must be a int greater than 0")
-		}
-		meta.watchProgressNotifyInterval = interval
-	}
-
-	if err = parseEtcdAuthParams(config, meta); err != nil {
-		return meta, err
-	}
-
+	meta.enableTLS = meta.TLS == etcdTLSEnable
 	meta.triggerIndex = config.TriggerIndex
 	return meta, nil
 }
@@ -155,19 +131,21 @@ func getEtcdClients(metadata *etcdMetadata) (*clientv3.Client, error) {
 	var tlsConfig *tls.Config
 	var err error
 	if metadata.enableTLS {
-		tlsConfig, err = kedautil.NewTLSConfigWithPassword(metadata.cert, metadata.key, metadata.keyPassword, metadata.ca, false)
+		tlsConfig, err = kedautil.NewTLSConfigWithPassword(metadata.Cert, metadata.Key, metadata.KeyPassword, metadata.CA, false)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	cli, err := clientv3.New(clientv3.Config{
-		Endpoints:   metadata.endpoints,
+		Endpoints:   metadata.Endpoints,
 		DialTimeout: 5 * time.Second,
 		TLS:         tlsConfig,
+		Username:    metadata.Username,
+		Password:    metadata.Password,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error connecting to etcd server: %w", err)
+		return nil, fmt.Errorf("error connecting to etcd server: %w", redactSecrets(err, metadata.Password))
 	}
 
 	return cli, nil
@@ -189,16 +167,16 @@ func (s *etcdScaler) GetMetricsAndActivity(ctx context.Context, metricName strin
 	}
 
 	metric := GenerateMetricInMili(metricName, v)
-	return append([]external_metrics.ExternalMetricValue{}, metric), v > s.metadata.activationValue, nil
+	return append([]external_metrics.ExternalMetricValue{}, metric), v > s.metadata.ActivationValue, nil
 }
 
 // GetMetricSpecForScaling returns the metric spec for the HPA.
 func (s *etcdScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("etcd-%s", s.metadata.watchKey))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("etcd-%s", s.metadata.WatchKey))),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: etcdMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -209,16 +187,16 @@ func (s *etcdScaler) Run(ctx context.Context, active chan<- bool) {
 
 	// It's possible for the watch to get terminated anytime, we need to run this in a retry loop
 	runWithWatch := func() {
-		s.logger.Info("run watch", "watchKey", s.metadata.watchKey, "endpoints", s.metadata.endpoints)
+		s.logger.Info("run watch", "watchKey", s.metadata.WatchKey, "endpoints", s.metadata.Endpoints)
 		subCtx, cancel := context.WithCancel(ctx)
 		subCtx = clientv3.WithRequireLeader(subCtx)
-		rch := s.client.Watch(subCtx, s.metadata.watchKey, clientv3.WithProgressNotify())
+		rch := s.client.Watch(subCtx, s.metadata.WatchKey, clientv3.WithProgressNotify())
 
 		// rewatch to another etcd server when the network is isolated from the current etcd server.
 		progress := make(chan bool)
 		defer close(progress)
 		go func() {
-			delayDuration := time.Duration(s.metadata.watchProgressNotifyInterval) * 2 * time.Second
+			delayDuration := time.Duration(s.metadata.WatchProgressNotifyInterval) * 2 * time.Second
 			delay := time.NewTimer(delayDuration)
 			defer delay.Stop()
 			for {
@@ -228,7 +206,7 @@ func (s *etcdScaler) Run(ctx context.Context, active chan<- bool) {
 				case <-subCtx.Done():
 					return
 				case <-delay.C:
-					s.logger.Info("no watch progress notification in the interval", "watchKey", s.metadata.watchKey, "endpoints", s.metadata.endpoints)
+					s.logger.Info("no watch progress notification in the interval", "watchKey", s.metadata.WatchKey, "endpoints", s.metadata.Endpoints)
 					cancel()
 					return
 				}
@@ -240,7 +218,7 @@ func (s *etcdScaler) Run(ctx context.Context, active chan<- bool) {
 
 			// rewatch to another etcd server when there is an error form the current etcd server, such as 'no leader','required revision has been compacted'
 			if wresp.Err() != nil {
-				s.logger.Error(wresp.Err(), "an error occurred in the watch process", "watchKey", s.metadata.watchKey, "endpoints", s.metadata.endpoints)
+				s.logger.Error(wresp.Err(), "an error occurred in the watch process", "watchKey", s.metadata.WatchKey, "endpoints", s.metadata.Endpoints)
 				cancel()
 				return
 			}
@@ -251,7 +229,7 @@ func (s *etcdScaler) Run(ctx context.Context, active chan<- bool) {
 					s.logger.Error(err, "etcdValue invalid will be treated as 0")
 					v = 0
 				}
-				active <- v > s.metadata.activationValue
+				active <- v > s.metadata.ActivationValue
 			}
 		}
 	}
@@ -288,12 +266,12 @@ func (s *etcdScaler) Run(ctx context.Context, active chan<- bool) {
 func (s *etcdScaler) getMetricValue(ctx context.Context) (float64, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
-	resp, err := s.client.Get(ctx, s.metadata.watchKey)
+	resp, err := s.client.Get(ctx, s.metadata.WatchKey)
 	if err != nil {
 		return 0, err
 	}
 	if resp.Kvs == nil {
-		return 0, fmt.Errorf("watchKey %s doesn't exist", s.metadata.watchKey)
+		return 0, fmt.Errorf("watchKey %s doesn't exist", s.metadata.WatchKey)
 	}
 	v, err := strconv.ParseFloat(string(resp.Kvs[0].Value), 64)
 	if err != nil {
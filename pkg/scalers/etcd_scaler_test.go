@@ -14,12 +14,14 @@ type parseEtcdMetadataTestData struct {
 	metadata  map[string]string
 	endpoints []string
 	isError   bool
+	parity    *metadataParity
 }
 
 type parseEtcdAuthParamsTestData struct {
 	authParams map[string]string
 	isError    bool
 	enableTLS  bool
+	parity     *metadataParity
 }
 
 type etcdMetricIdentifier struct {
@@ -39,36 +41,48 @@ var validEtcdMetadata = map[string]string{
 
 var parseEtcdMetadataTestDataset = []parseEtcdMetadataTestData{
 	// success
-	{map[string]string{"endpoints": "172.0.0.1:2379,172.0.0.2:2379,172.0.0.3:2379", "watchKey": "length", "value": "5.5", "activationValue": "0.5", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379", "172.0.0.2:2379", "172.0.0.3:2379"}, false},
+	{map[string]string{"endpoints": "172.0.0.1:2379,172.0.0.2:2379,172.0.0.3:2379", "watchKey": "length", "value": "5.5", "activationValue": "0.5", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379", "172.0.0.2:2379", "172.0.0.3:2379"}, false, nil},
 	// success
-	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "var", "value": "5.5", "activationValue": "0.5", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, false},
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "var", "value": "5.5", "activationValue": "0.5", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, false, nil},
 	// failure, endpoints missed
-	{map[string]string{"endpoints": "", "watchKey": "length", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{""}, true},
+	{map[string]string{"endpoints": "", "watchKey": "length", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{""}, true, nil},
 	// failure, watchKey missed
-	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true},
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true, nil},
 	// failure, value invalid
-	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "a", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true},
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "a", "activationValue": "0", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true, nil},
 	// failure, activationValue invalid
-	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "5", "activationValue": "b", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true},
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "5", "activationValue": "b", "watchProgressNotifyInterval": "600"}, []string{"172.0.0.1:2379"}, true, nil},
 	// failure, watchProgressNotifyInterval invalid
-	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "0"}, []string{"172.0.0.1:2379"}, true},
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "5", "activationValue": "0", "watchProgressNotifyInterval": "0"}, []string{"172.0.0.1:2379"}, true, nil},
+	// success, endpoints with spaces and URL
+	{map[string]string{"endpoints": "172.0.0.1:2379, https://etcd-0.etcd:2379", "watchKey": "length", "value": "5"}, []string{"172.0.0.1:2379", "https://etcd-0.etcd:2379"}, false, &metadataParity{fields: map[string]any{"Value": 5, "ActivationValue": 0, "WatchProgressNotifyInterval": 600, "enableTLS": false}}},
+	// failure, one of multiple endpoints without port
+	{map[string]string{"endpoints": "172.0.0.1:2379,172.0.0.2,172.0.0.3:2379", "watchKey": "length", "value": "5"}, nil, true, nil},
+	// failure, one of multiple endpoints with invalid port
+	{map[string]string{"endpoints": "172.0.0.1:2379,172.0.0.2:etcd", "watchKey": "length", "value": "5"}, nil, true, nil},
+	// failure, value not greater than 0
+	{map[string]string{"endpoints": "172.0.0.1:2379", "watchKey": "length", "value": "0"}, nil, true, nil},
 }
 
 var parseEtcdAuthParamsTestDataset = []parseEtcdAuthParamsTestData{
 	// success, TLS only
-	{map[string]string{"tls": "enable", "ca": "caaa", "cert": "ceert", "key": "keey"}, false, true},
+	{map[string]string{"tls": "enable", "ca": "caaa", "cert": "ceert", "key": "keey"}, false, true, nil},
 	// success, TLS cert/key and assumed public CA
-	{map[string]string{"tls": "enable", "cert": "ceert", "key": "keey"}, false, true},
+	{map[string]string{"tls": "enable", "cert": "ceert", "key": "keey"}, false, true, nil},
 	// success, TLS cert/key + key password and assumed public CA
-	{map[string]string{"tls": "enable", "cert": "ceert", "key": "keey", "keyPassword": "keeyPassword"}, false, true},
+	{map[string]string{"tls": "enable", "cert": "ceert", "key": "keey", "keyPassword": "keeyPassword"}, false, true, nil},
 	// success, TLS CA only
-	{map[string]string{"tls": "enable", "ca": "caaa"}, false, true},
+	{map[string]string{"tls": "enable", "ca": "caaa"}, false, true, nil},
 	// failure, TLS missing cert
-	{map[string]string{"tls": "enable", "ca": "caaa", "key": "keey"}, true, false},
+	{map[string]string{"tls": "enable", "ca": "caaa", "key": "keey"}, true, false, nil},
 	// failure, TLS missing key
-	{map[string]string{"tls": "enable", "ca": "caaa", "cert": "ceert"}, true, false},
+	{map[string]string{"tls": "enable", "ca": "caaa", "cert": "ceert"}, true, false, nil},
 	// failure, TLS invalid
-	{map[string]string{"tls": "yes", "ca": "caaa", "cert": "ceert", "key": "keey"}, true, false},
+	{map[string]string{"tls": "yes", "ca": "caaa", "cert": "ceert", "key": "keey"}, true, false, nil},
+	// success, username and password
+	{map[string]string{"username": "user", "password": "pass"}, false, false, &metadataParity{fields: map[string]any{"Username": "user", "Password": "pass"}}},
+	// failure, password without username
+	{map[string]string{"password": "pass"}, true, false, nil},
 }
 
 var etcdMetricIdentifiers = []etcdMetricIdentifier{
@@ -85,8 +99,9 @@ func TestParseEtcdMetadata(t *testing.T) {
 		if testData.isError && err == nil {
 			t.Error("Expected error but got success")
 		}
-		if err == nil && !reflect.DeepEqual(meta.endpoints, testData.endpoints) {
-			t.Errorf("Expected  %v but got %v\n", testData.endpoints, meta.endpoints)
+		checkMetadataParity(t, meta, err, testData.parity)
+		if err == nil && !reflect.DeepEqual(meta.Endpoints, testData.endpoints) {
+			t.Errorf("Expected  %v but got %v\n", testData.endpoints, meta.Endpoints)
 		}
 	}
 }
@@ -101,21 +116,25 @@ func TestParseEtcdAuthParams(t *testing.T) {
 		if testData.isError && err == nil {
 			t.Error("Expected error but got success")
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
+		if err != nil {
+			continue
+		}
 		if meta.enableTLS != testData.enableTLS {
 			t.Errorf("Expected enableTLS to be set to %v but got %v\n", testData.enableTLS, meta.enableTLS)
 		}
 		if meta.enableTLS {
-			if meta.ca != testData.authParams["ca"] {
+			if meta.CA != testData.authParams["ca"] {
 				t.Errorf("Expected ca to be set to %v but got %v\n", testData.authParams["ca"], meta.enableTLS)
 			}
-			if meta.cert != testData.authParams["cert"] {
-				t.Errorf("Expected cert to be set to %v but got %v\n", testData.authParams["cert"], meta.cert)
+			if meta.Cert != testData.authParams["cert"] {
+				t.Errorf("Expected cert to be set to %v but got %v\n", testData.authParams["cert"], meta.Cert)
 			}
-			if meta.key != testData.authParams["key"] {
-				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["key"], meta.key)
+			if meta.Key != testData.authParams["key"] {
+				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["key"], meta.Key)
 			}
-			if meta.keyPassword != testData.authParams["keyPassword"] {
-				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["keyPassword"], meta.key)
+			if meta.KeyPassword != testData.authParams["keyPassword"] {
+				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["keyPassword"], meta.Key)
 			}
 		}
 	}
@@ -3,6 +3,7 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 	"sync"
@@ -34,14 +35,37 @@ type externalPushScaler struct {
 }
 
 type externalScalerMetadata struct {
-	scalerAddress    string
-	tlsCertFile      string
+	ScalerAddress string `keda:"name=scalerAddress, order=triggerMetadata"`
+	TLSCertFile   string `keda:"name=tlsCertFile,   order=triggerMetadata, optional"`
+	CACert        string `keda:"name=caCert,        order=authParams,      optional"`
+	TLSClientCert string `keda:"name=tlsClientCert, order=authParams,      optional"`
+	TLSClientKey  string `keda:"name=tlsClientKey,  order=authParams,      optional"`
+	UnsafeSsl     bool   `keda:"name=unsafeSsl,     order=triggerMetadata, default=false"`
+
+	// originalMetadata is the whole trigger metadata forwarded to the external scaler,
+	// including the parameters the typed config doesn't know about
 	originalMetadata map[string]string
 	triggerIndex     int
-	caCert           string
-	tlsClientCert    string
-	tlsClientKey     string
-	unsafeSsl        bool
+}
+
+func (m *externalScalerMetadata) Validate() error {
+	return validateExternalScalerAddress(m.ScalerAddress)
+}
+
+// validateExternalScalerAddress checks the scalerAddress is either host:port or a gRPC target
+// with a resolver scheme, e.g. dns:///myservice:6000
+func validateExternalScalerAddress(address string) error {
+	if scheme, _, found := strings.Cut(address, "://"); found && scheme != "" {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || host == "" {
+		return fmt.Errorf("scalerAddress must be in the host:port format, got %q", address)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("scalerAddress port must be a number between 0 and 65535, got %q", port)
+	}
+	return nil
 }
 
 type connectionGroup struct {
@@ -105,43 +129,13 @@ func NewExternalPushScaler(config *scalersconfig.ScalerConfig) (PushScaler, erro
 }
 
 func parseExternalScalerMetadata(config *scalersconfig.ScalerConfig) (externalScalerMetadata, error) {
-	meta := externalScalerMetadata{
-		originalMetadata: config.TriggerMetadata,
-	}
-
-	// Check if scalerAddress is present
-	if val, ok := config.TriggerMetadata["scalerAddress"]; ok && val != "" {
-		meta.scalerAddress = val
-	} else {
-		return meta, fmt.Errorf("scaler Address is a required field")
-	}
-
-	if val, ok := config.TriggerMetadata["tlsCertFile"]; ok && val != "" {
-		meta.tlsCertFile = val
-	}
-
-	meta.originalMetadata = make(map[string]string)
-	if val, ok := config.AuthParams["caCert"]; ok {
-		meta.caCert = val
-	}
-
-	if val, ok := config.AuthParams["tlsClientCert"]; ok {
-		meta.tlsClientCert = val
+	meta := externalScalerMetadata{}
+	if err := config.TypedConfig(&meta); err != nil {
+		return meta, err
 	}
 
-	if val, ok := config.AuthParams["tlsClientKey"]; ok {
-		meta.tlsClientKey = val
-	}
-
-	meta.unsafeSsl = false
-	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok && val != "" {
-		boolVal, err := strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("failed to parse insecureSkipVerify value. Must be either true or false")
-		}
-		meta.unsafeSsl = boolVal
-	}
 	// Add elements to metadata
+	meta.originalMetadata = make(map[string]string)
 	for key, value := range config.TriggerMetadata {
 		// Check if key is in resolved environment and resolve
 		if strings.HasSuffix(key, "FromEnv") {
@@ -307,38 +301,38 @@ func getClientForConnectionPool(metadata externalScalerMetadata, logger logr.Log
 
 	buildGRPCConnection := func(metadata externalScalerMetadata) (*grpc.ClientConn, error) {
 		// FIXME: DEPRECATED to be removed in v2.13 https://github.com/kedacore/keda/issues/4549
-		if metadata.tlsCertFile != "" {
+		if metadata.TLSCertFile != "" {
 			logger.V(1).Info("tlsCertFile in ScaleObject metadata will be deprecated in v2.12. Please use" +
 				"tlsClientCert, tlsClientKey and caCert in TriggerAuthentication instead.")
-			creds, err := credentials.NewClientTLSFromFile(metadata.tlsCertFile, "")
+			creds, err := credentials.NewClientTLSFromFile(metadata.TLSCertFile, "")
 			if err != nil {
 				return nil, err
 			}
-			return grpc.Dial(metadata.scalerAddress,
+			return grpc.Dial(metadata.ScalerAddress,
 				grpc.WithDefaultServiceConfig(grpcConfig),
 				grpc.WithTransportCredentials(creds))
 		}
 
-		tlsConfig, err := util.NewTLSConfig(metadata.tlsClientCert, metadata.tlsClientKey, metadata.caCert, metadata.unsafeSsl)
+		tlsConfig, err := util.NewTLSConfig(metadata.TLSClientCert, metadata.TLSClientKey, metadata.CACert, metadata.UnsafeSsl)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(tlsConfig.Certificates) > 0 || metadata.caCert != "" {
+		if len(tlsConfig.Certificates) > 0 || metadata.CACert != "" {
 			// nosemgrep: go.grpc.ssrf.grpc-tainted-url-host.grpc-tainted-url-host
-			return grpc.Dial(metadata.scalerAddress,
+			return grpc.Dial(metadata.ScalerAddress,
 				grpc.WithDefaultServiceConfig(grpcConfig),
 				grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 		}
 
-		return grpc.Dial(metadata.scalerAddress,
+		return grpc.Dial(metadata.ScalerAddress,
 			grpc.WithDefaultServiceConfig(grpcConfig),
 			grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
 	// create a unique key per-metadata. If scaledObjects share the same connection properties
 	// in the metadata, they will share the same grpc.ClientConn
-	key, err := hashstructure.Hash(metadata.scalerAddress, nil)
+	key, err := hashstructure.Hash(metadata.ScalerAddress, nil)
 	if err != nil {
 		return nil, err
 	}
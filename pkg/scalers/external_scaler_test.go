@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
@@ -68,9 +70,17 @@ type parseExternalScalerMetadataTestData struct {
 var testExternalScalerMetadata = []parseExternalScalerMetadataTestData{
 	{map[string]string{}, true, map[string]string{}},
 	// all properly formed
-	{map[string]string{"scalerAddress": "myservice", "test1": "7", "test2": "SAMPLE_CREDS", "insecureSkipVerify": "true"}, false, map[string]string{"caCert": serverRootCA, "tlsClientCert": clientCert}},
+	{map[string]string{"scalerAddress": "myservice:6000", "test1": "7", "test2": "SAMPLE_CREDS", "insecureSkipVerify": "true"}, false, map[string]string{"caCert": serverRootCA, "tlsClientCert": clientCert}},
+	// gRPC target with a resolver scheme
+	{map[string]string{"scalerAddress": "dns:///myservice:6000", "unsafeSsl": "true"}, false, map[string]string{}},
 	// missing scalerAddress
 	{map[string]string{"test1": "1", "test2": "SAMPLE_CREDS"}, true, map[string]string{}},
+	// scalerAddress without port
+	{map[string]string{"scalerAddress": "myservice"}, true, map[string]string{}},
+	// scalerAddress with invalid port
+	{map[string]string{"scalerAddress": "myservice:port"}, true, map[string]string{}},
+	// invalid unsafeSsl
+	{map[string]string{"scalerAddress": "myservice:6000", "unsafeSsl": "yes please"}, true, map[string]string{}},
 }
 
 func TestExternalScalerParseMetadata(t *testing.T) {
@@ -80,8 +90,8 @@ func TestExternalScalerParseMetadata(t *testing.T) {
 			t.Error("Expected success but got error", err)
 		}
 
-		if testData.metadata["unsafeSsl"] == "true" && !metadata.unsafeSsl {
-			t.Error("Expected unsafeSsl to be true but got", metadata.unsafeSsl)
+		if testData.metadata["unsafeSsl"] == "true" && !metadata.UnsafeSsl {
+			t.Error("Expected unsafeSsl to be true but got", metadata.UnsafeSsl)
 		}
 		if testData.isError && err == nil {
 			t.Error("Expected error but got success")
@@ -89,6 +99,68 @@ func TestExternalScalerParseMetadata(t *testing.T) {
 	}
 }
 
+func TestExternalScalerForwardsMetadata(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &metadataRecordingExternalScaler{}
+	pb.RegisterExternalScalerServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	defer grpcServer.Stop()
+
+	triggerMetadata := map[string]string{
+		"scalerAddress":   lis.Addr().String(),
+		"unsafeSsl":       "false",
+		"queueName":       "orders",
+		"custom.key":      "value, with=separators;",
+		"passwordFromEnv": "PASSWORD",
+	}
+	s, err := NewExternalScaler(&scalersconfig.ScalerConfig{
+		ScalableObjectName:      "app",
+		ScalableObjectNamespace: "namespace",
+		TriggerMetadata:         triggerMetadata,
+		ResolvedEnv:             map[string]string{"PASSWORD": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("Expected success but got error %v", err)
+	}
+	s.GetMetricSpecForScaling(context.Background())
+
+	expected := map[string]string{
+		"scalerAddress":   lis.Addr().String(),
+		"unsafeSsl":       "false",
+		"queueName":       "orders",
+		"custom.key":      "value, with=separators;",
+		"passwordFromEnv": "secret",
+	}
+	assert.Equal(t, expected, server.scalerMetadata())
+}
+
+// metadataRecordingExternalScaler records the metadata of the received ScaledObjectRef
+type metadataRecordingExternalScaler struct {
+	pb.UnimplementedExternalScalerServer
+
+	mu       sync.Mutex
+	metadata map[string]string
+}
+
+func (e *metadataRecordingExternalScaler) GetMetricSpec(_ context.Context, ref *pb.ScaledObjectRef) (*pb.GetMetricSpecResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.metadata = ref.ScalerMetadata
+	return &pb.GetMetricSpecResponse{MetricSpecs: []*pb.MetricSpec{{MetricName: "metric", TargetSize: 1}}}, nil
+}
+
+func (e *metadataRecordingExternalScaler) scalerMetadata() map[string]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.metadata
+}
+
 func TestExternalPushScaler_Run(t *testing.T) {
 	const serverCount = 5
 	const iterationCount = 500
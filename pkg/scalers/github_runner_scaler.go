@@ -3,6 +3,7 @@ package scalers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,7 +22,6 @@ import (
 
 const (
 	defaultTargetWorkflowQueueLength = 1
-	defaultGithubAPIURL              = "https://api.github.com"
 	ORG                              = "org"
 	ENT                              = "ent"
 	REPO                             = "repo"
@@ -37,17 +37,18 @@ type githubRunnerScaler struct {
 }
 
 type githubRunnerMetadata struct {
-	githubAPIURL              string
-	owner                     string
-	runnerScope               string
-	personalAccessToken       *string
-	repos                     []string
-	labels                    []string
+	GithubAPIURL        string   `keda:"name=githubApiURL,        order=triggerMetadata;resolvedEnv, default=https://api.github.com"`
+	Owner               string   `keda:"name=owner,               order=triggerMetadata;resolvedEnv, optional"`
+	RunnerScope         string   `keda:"name=runnerScope,         order=triggerMetadata;resolvedEnv, enum=org;ent;repo"`
+	PersonalAccessToken *string  `keda:"name=personalAccessToken, order=authParams,                  optional, sensitive, exclusiveSet=ApplicationID;InstallationID;ApplicationKey"`
+	Repos               []string `keda:"name=repos,               order=triggerMetadata;resolvedEnv, optional, requiredIf=RunnerScope:repo"`
+	Labels              []string `keda:"name=labels,              order=triggerMetadata;resolvedEnv, optional"`
+	ApplicationID       *int64   `keda:"name=applicationID,       order=triggerMetadata;resolvedEnv, optional, requiredIf=InstallationID;ApplicationKey"`
+	InstallationID      *int64   `keda:"name=installationID,      order=triggerMetadata;resolvedEnv, optional, requiredIf=ApplicationID;ApplicationKey"`
+	ApplicationKey      *string  `keda:"name=appKey,              order=authParams,                  optional, sensitive, requiredIf=ApplicationID;InstallationID"`
+
 	targetWorkflowQueueLength int64
 	triggerIndex              int
-	applicationID             *int64
-	installationID            *int64
-	applicationKey            *string
 }
 
 type WorkflowRuns struct {
@@ -339,13 +340,13 @@ func NewGitHubRunnerScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing GitHub Runner metadata: %w", err)
 	}
 
-	if meta.applicationID != nil && meta.installationID != nil && meta.applicationKey != nil {
+	if meta.ApplicationID != nil && meta.InstallationID != nil && meta.ApplicationKey != nil {
 		httpTrans := kedautil.CreateHTTPTransport(false)
-		hc, err := gha.New(httpTrans, *meta.applicationID, *meta.installationID, []byte(*meta.applicationKey))
+		hc, err := gha.New(httpTrans, *meta.ApplicationID, *meta.InstallationID, []byte(*meta.ApplicationKey))
 		if err != nil {
-			return nil, fmt.Errorf("error creating GitHub App client: %w, \n appID: %d, instID: %d", err, meta.applicationID, meta.installationID)
+			return nil, fmt.Errorf("error creating GitHub App client: %w, \n appID: %d, instID: %d", err, *meta.ApplicationID, *meta.InstallationID)
 		}
-		hc.BaseURL = meta.githubAPIURL
+		hc.BaseURL = meta.GithubAPIURL
 		httpClient = &http.Client{Transport: hc}
 	}
 
@@ -357,129 +358,50 @@ func NewGitHubRunnerScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	}, nil
 }
 
-// getValueFromMetaOrEnv returns the value of the given key from the metadata or the environment variables
-func getValueFromMetaOrEnv(key string, metadata map[string]string, env map[string]string) (string, error) {
-	if val, ok := metadata[key]; ok && val != "" {
-		return val, nil
-	} else if val, ok := metadata[key+"FromEnv"]; ok && val != "" {
-		return env[val], nil
+func (m *githubRunnerMetadata) Validate() error {
+	if m.Owner == "" {
+		return errors.New("no owner given")
 	}
-	return "", fmt.Errorf("no %s given", key)
-}
-
-// getInt64ValueFromMetaOrEnv returns the value of the given key from the metadata or the environment variables
-func getInt64ValueFromMetaOrEnv(key string, config *scalersconfig.ScalerConfig) (int64, error) {
-	sInt, err := getValueFromMetaOrEnv(key, config.TriggerMetadata, config.ResolvedEnv)
-	if err != nil {
-		return -1, fmt.Errorf("error parsing %s: %w", key, err)
+	hasApp := m.ApplicationID != nil || m.InstallationID != nil || m.ApplicationKey != nil
+	if !hasApp && m.PersonalAccessToken == nil {
+		return errors.New("no personalAccessToken or appKey given")
 	}
-
-	goodInt, err := strconv.ParseInt(sInt, 10, 64)
-	if err != nil {
-		return -1, fmt.Errorf("error parsing %s: %w", key, err)
-	}
-	return goodInt, nil
+	return nil
 }
 
 func parseGitHubRunnerMetadata(config *scalersconfig.ScalerConfig) (*githubRunnerMetadata, error) {
-	meta := &githubRunnerMetadata{}
-	meta.targetWorkflowQueueLength = defaultTargetWorkflowQueueLength
-
-	if val, err := getValueFromMetaOrEnv("runnerScope", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
-		meta.runnerScope = val
-	} else {
-		return nil, err
-	}
-
-	if val, err := getValueFromMetaOrEnv("owner", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
-		meta.owner = val
-	} else {
-		return nil, err
-	}
-
-	if val, err := getInt64ValueFromMetaOrEnv("targetWorkflowQueueLength", config); err == nil && val != -1 {
-		meta.targetWorkflowQueueLength = val
-	} else {
-		meta.targetWorkflowQueueLength = defaultTargetWorkflowQueueLength
-	}
-
-	if val, err := getValueFromMetaOrEnv("labels", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
-		meta.labels = strings.Split(val, ",")
-	}
-
-	if val, err := getValueFromMetaOrEnv("repos", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
-		meta.repos = strings.Split(val, ",")
-	}
-
-	if val, err := getValueFromMetaOrEnv("githubApiURL", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
-		meta.githubAPIURL = val
-	} else {
-		meta.githubAPIURL = defaultGithubAPIURL
-	}
-
-	if val, ok := config.AuthParams["personalAccessToken"]; ok && val != "" {
-		// Found the pat token in a parameter from TriggerAuthentication
-		meta.personalAccessToken = &val
+	meta := &githubRunnerMetadata{
+		targetWorkflowQueueLength: defaultTargetWorkflowQueueLength,
+		triggerIndex:              config.TriggerIndex,
 	}
-
-	if appID, instID, key, err := setupGitHubApp(config); err == nil {
-		meta.applicationID = appID
-		meta.installationID = instID
-		meta.applicationKey = key
-	} else {
+	if err := config.TypedConfig(meta); err != nil {
 		return nil, err
 	}
 
-	if meta.applicationKey == nil && meta.personalAccessToken == nil {
-		return nil, fmt.Errorf("no personalAccessToken or appKey given")
+	// invalid values fall back to the default for backwards compatibility
+	if val, ok, err := config.GetInt("targetWorkflowQueueLength", scalersconfig.TriggerMetadata, scalersconfig.ResolvedEnv); ok && err == nil {
+		meta.targetWorkflowQueueLength = int64(val)
 	}
 
-	meta.triggerIndex = config.TriggerIndex
-
 	return meta, nil
 }
 
-func setupGitHubApp(config *scalersconfig.ScalerConfig) (*int64, *int64, *string, error) {
-	var appID *int64
-	var instID *int64
-	var appKey *string
-
-	if val, err := getInt64ValueFromMetaOrEnv("applicationID", config); err == nil && val != -1 {
-		appID = &val
-	}
-
-	if val, err := getInt64ValueFromMetaOrEnv("installationID", config); err == nil && val != -1 {
-		instID = &val
-	}
-
-	if val, ok := config.AuthParams["appKey"]; ok && val != "" {
-		appKey = &val
-	}
-
-	if (appID != nil || instID != nil || appKey != nil) &&
-		(appID == nil || instID == nil || appKey == nil) {
-		return nil, nil, nil, fmt.Errorf("applicationID, installationID and applicationKey must be given")
-	}
-
-	return appID, instID, appKey, nil
-}
-
 // getRepositories returns a list of repositories for a given organization, user or enterprise
 func (s *githubRunnerScaler) getRepositories(ctx context.Context) ([]string, error) {
-	if s.metadata.repos != nil {
-		return s.metadata.repos, nil
+	if s.metadata.Repos != nil {
+		return s.metadata.Repos, nil
 	}
 
 	var url string
-	switch s.metadata.runnerScope {
+	switch s.metadata.RunnerScope {
 	case ORG:
-		url = fmt.Sprintf("%s/orgs/%s/repos", s.metadata.githubAPIURL, s.metadata.owner)
+		url = fmt.Sprintf("%s/orgs/%s/repos", s.metadata.GithubAPIURL, s.metadata.Owner)
 	case REPO:
-		url = fmt.Sprintf("%s/users/%s/repos", s.metadata.githubAPIURL, s.metadata.owner)
+		url = fmt.Sprintf("%s/users/%s/repos", s.metadata.GithubAPIURL, s.metadata.Owner)
 	case ENT:
-		url = fmt.Sprintf("%s/orgs/%s/repos", s.metadata.githubAPIURL, s.metadata.owner)
+		url = fmt.Sprintf("%s/orgs/%s/repos", s.metadata.GithubAPIURL, s.metadata.Owner)
 	default:
-		return nil, fmt.Errorf("runnerScope %s not supported", s.metadata.runnerScope)
+		return nil, fmt.Errorf("runnerScope %s not supported", s.metadata.RunnerScope)
 	}
 	body, _, err := getGithubRequest(ctx, url, s.metadata, s.httpClient)
 	if err != nil {
@@ -509,8 +431,8 @@ func getGithubRequest(ctx context.Context, url string, metadata *githubRunnerMet
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
-	if metadata.applicationID == nil && metadata.personalAccessToken != nil {
-		req.Header.Set("Authorization", "Bearer "+*metadata.personalAccessToken)
+	if metadata.ApplicationID == nil && metadata.PersonalAccessToken != nil {
+		req.Header.Set("Authorization", "Bearer "+*metadata.PersonalAccessToken)
 	}
 
 	r, err := httpClient.Do(req)
@@ -554,7 +476,7 @@ func stripDeadRuns(allWfrs []WorkflowRuns) []WorkflowRun {
 
 // getWorkflowRunJobs returns a list of jobs for a given workflow run
 func (s *githubRunnerScaler) getWorkflowRunJobs(ctx context.Context, workflowRunID int64, repoName string) ([]Job, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/jobs", s.metadata.githubAPIURL, s.metadata.owner, repoName, workflowRunID)
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/jobs", s.metadata.GithubAPIURL, s.metadata.Owner, repoName, workflowRunID)
 	body, _, err := getGithubRequest(ctx, url, s.metadata, s.httpClient)
 	if err != nil {
 		return nil, err
@@ -571,7 +493,7 @@ func (s *githubRunnerScaler) getWorkflowRunJobs(ctx context.Context, workflowRun
 
 // getWorkflowRuns returns a list of workflow runs for a given repository
 func (s *githubRunnerScaler) getWorkflowRuns(ctx context.Context, repoName string) (*WorkflowRuns, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs", s.metadata.githubAPIURL, s.metadata.owner, repoName)
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs", s.metadata.GithubAPIURL, s.metadata.Owner, repoName)
 	body, statusCode, err := getGithubRequest(ctx, url, s.metadata, s.httpClient)
 	if err != nil && statusCode == 404 {
 		return nil, nil
@@ -638,7 +560,7 @@ func (s *githubRunnerScaler) GetWorkflowQueueLength(ctx context.Context) (int64,
 			return -1, err
 		}
 		for _, job := range jobs {
-			if (job.Status == "queued" || job.Status == "in_progress") && canRunnerMatchLabels(job.Labels, s.metadata.labels) {
+			if (job.Status == "queued" || job.Status == "in_progress") && canRunnerMatchLabels(job.Labels, s.metadata.Labels) {
 				queueCount++
 			}
 		}
@@ -663,7 +585,7 @@ func (s *githubRunnerScaler) GetMetricsAndActivity(ctx context.Context, metricNa
 func (s *githubRunnerScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("github-runner-%s", s.metadata.owner))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("github-runner-%s", s.metadata.Owner))),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.targetWorkflowQueueLength),
 	}
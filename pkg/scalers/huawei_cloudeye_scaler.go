@@ -18,14 +18,6 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
-const (
-	defaultCloudeyeMetricCollectionTime = 300
-	defaultCloudeyeMetricFilter         = "average"
-	defaultCloudeyeMetricPeriod         = "300"
-
-	defaultHuaweiCloud = "myhuaweicloud.com"
-)
-
 type huaweiCloudeyeScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *huaweiCloudeyeMetadata
@@ -33,42 +25,44 @@ type huaweiCloudeyeScaler struct {
 }
 
 type huaweiCloudeyeMetadata struct {
-	namespace      string
-	metricsName    string
-	dimensionName  string
-	dimensionValue string
+	Namespace      string `keda:"name=namespace,      order=triggerMetadata"`
+	MetricsName    string `keda:"name=metricName,     order=triggerMetadata"`
+	DimensionName  string `keda:"name=dimensionName,  order=triggerMetadata"`
+	DimensionValue string `keda:"name=dimensionValue, order=triggerMetadata"`
 
-	targetMetricValue           float64
-	activationTargetMetricValue float64
+	TargetMetricValue           float64 `keda:"name=targetMetricValue,           order=triggerMetadata"`
+	ActivationTargetMetricValue float64 `keda:"name=activationTargetMetricValue, order=triggerMetadata, default=0"`
+	// MinMetricValue is deprecated in favor of activationTargetMetricValue, it's still required and takes precedence
+	MinMetricValue float64 `keda:"name=minMetricValue, order=triggerMetadata"`
 
-	metricCollectionTime int64
-	metricFilter         string
-	metricPeriod         string
+	MetricCollectionTime int64  `keda:"name=metricCollectionTime, order=triggerMetadata, default=300, min=1"`
+	MetricFilter         string `keda:"name=metricFilter,         order=triggerMetadata, default=average"`
+	MetricPeriod         int    `keda:"name=metricPeriod,         order=triggerMetadata, default=300, min=1"`
 
-	huaweiAuthorization huaweiAuthorizationMetadata
+	HuaweiAuthorization huaweiAuthorizationMetadata `keda:""`
 
 	triggerIndex int
 }
 
 type huaweiAuthorizationMetadata struct {
-	IdentityEndpoint string
+	IdentityEndpoint string `keda:"name=IdentityEndpoint, order=authParams"`
 
 	// user project id
-	ProjectID string
+	ProjectID string `keda:"name=ProjectID, order=authParams"`
 
-	DomainID string
+	DomainID string `keda:"name=DomainID, order=authParams"`
 
 	// region
-	Region string
+	Region string `keda:"name=Region, order=authParams"`
 
 	// Cloud name
-	Domain string
+	Domain string `keda:"name=Domain, order=authParams"`
 
 	// Cloud name
-	Cloud string
+	Cloud string `keda:"name=Cloud, order=authParams, default=myhuaweicloud.com"`
 
-	AccessKey string // Access Key
-	SecretKey string // Secret key
+	AccessKey string `keda:"name=AccessKey, order=authParams"` // Access Key
+	SecretKey string `keda:"name=SecretKey, order=authParams"` // Secret key
 }
 
 // NewHuaweiCloudeyeScaler creates a new huaweiCloudeyeScaler
@@ -94,153 +88,17 @@ func NewHuaweiCloudeyeScaler(config *scalersconfig.ScalerConfig) (Scaler, error)
 
 func parseHuaweiCloudeyeMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*huaweiCloudeyeMetadata, error) {
 	meta := huaweiCloudeyeMetadata{}
-
-	meta.metricCollectionTime = defaultCloudeyeMetricCollectionTime
-	meta.metricFilter = defaultCloudeyeMetricFilter
-	meta.metricPeriod = defaultCloudeyeMetricPeriod
-
-	if val, ok := config.TriggerMetadata["namespace"]; ok && val != "" {
-		meta.namespace = val
-	} else {
-		return nil, fmt.Errorf("namespace not given")
-	}
-
-	if val, ok := config.TriggerMetadata["metricName"]; ok && val != "" {
-		meta.metricsName = val
-	} else {
-		return nil, fmt.Errorf("metric Name not given")
-	}
-
-	if val, ok := config.TriggerMetadata["dimensionName"]; ok && val != "" {
-		meta.dimensionName = val
-	} else {
-		return nil, fmt.Errorf("dimension Name not given")
-	}
-
-	if val, ok := config.TriggerMetadata["dimensionValue"]; ok && val != "" {
-		meta.dimensionValue = val
-	} else {
-		return nil, fmt.Errorf("dimension Value not given")
-	}
-
-	if val, ok := config.TriggerMetadata["targetMetricValue"]; ok && val != "" {
-		targetMetricValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			logger.Error(err, "Error parsing targetMetricValue metadata")
-		} else {
-			meta.targetMetricValue = targetMetricValue
-		}
-	} else {
-		return nil, fmt.Errorf("target Metric Value not given")
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, fmt.Errorf("error parsing huawei cloudeye metadata: %w", err)
 	}
 
-	meta.activationTargetMetricValue = 0
-	if val, ok := config.TriggerMetadata["activationTargetMetricValue"]; ok && val != "" {
-		activationTargetMetricValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			logger.Error(err, "Error parsing activationTargetMetricValue metadata")
-		}
-		meta.activationTargetMetricValue = activationTargetMetricValue
-	}
+	logger.Info("minMetricValue is deprecated and will be removed in next versions, please use activationTargetMetricValue instead")
+	meta.ActivationTargetMetricValue = meta.MinMetricValue
 
-	if val, ok := config.TriggerMetadata["minMetricValue"]; ok && val != "" {
-		minMetricValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			logger.Error(err, "Error parsing minMetricValue metadata")
-		} else {
-			logger.Error(err, "minMetricValue is deprecated and will be removed in next versions, please use activationTargetMetricValue instead")
-			meta.activationTargetMetricValue = minMetricValue
-		}
-	} else {
-		return nil, fmt.Errorf("min Metric Value not given")
-	}
-
-	if val, ok := config.TriggerMetadata["metricCollectionTime"]; ok && val != "" {
-		metricCollectionTime, err := strconv.Atoi(val)
-		if err != nil {
-			logger.Error(err, "Error parsing metricCollectionTime metadata")
-		} else {
-			meta.metricCollectionTime = int64(metricCollectionTime)
-		}
-	}
-
-	if val, ok := config.TriggerMetadata["metricFilter"]; ok && val != "" {
-		meta.metricFilter = val
-	}
-
-	if val, ok := config.TriggerMetadata["metricPeriod"]; ok && val != "" {
-		_, err := strconv.Atoi(val)
-		if err != nil {
-			logger.Error(err, "Error parsing metricPeriod metadata")
-		} else {
-			meta.metricPeriod = val
-		}
-	}
-
-	auth, err := gethuaweiAuthorization(config.AuthParams)
-	if err != nil {
-		return nil, err
-	}
-
-	meta.huaweiAuthorization = auth
 	meta.triggerIndex = config.TriggerIndex
 	return &meta, nil
 }
 
-func gethuaweiAuthorization(authParams map[string]string) (huaweiAuthorizationMetadata, error) {
-	meta := huaweiAuthorizationMetadata{}
-
-	if authParams["IdentityEndpoint"] != "" {
-		meta.IdentityEndpoint = authParams["IdentityEndpoint"]
-	} else {
-		return meta, fmt.Errorf("identityEndpoint doesn't exist in the authParams")
-	}
-
-	if authParams["ProjectID"] != "" {
-		meta.ProjectID = authParams["ProjectID"]
-	} else {
-		return meta, fmt.Errorf("projectID doesn't exist in the authParams")
-	}
-
-	if authParams["DomainID"] != "" {
-		meta.DomainID = authParams["DomainID"]
-	} else {
-		return meta, fmt.Errorf("domainID doesn't exist in the authParams")
-	}
-
-	if authParams["Region"] != "" {
-		meta.Region = authParams["Region"]
-	} else {
-		return meta, fmt.Errorf("region doesn't exist in the authParams")
-	}
-
-	if authParams["Domain"] != "" {
-		meta.Domain = authParams["Domain"]
-	} else {
-		return meta, fmt.Errorf("domain doesn't exist in the authParams")
-	}
-
-	if authParams["Cloud"] != "" {
-		meta.Cloud = authParams["Cloud"]
-	} else {
-		meta.Cloud = defaultHuaweiCloud
-	}
-
-	if authParams["AccessKey"] != "" {
-		meta.AccessKey = authParams["AccessKey"]
-	} else {
-		return meta, fmt.Errorf("accessKey doesn't exist in the authParams")
-	}
-
-	if authParams["SecretKey"] != "" {
-		meta.SecretKey = authParams["SecretKey"]
-	} else {
-		return meta, fmt.Errorf("secretKey doesn't exist in the authParams")
-	}
-
-	return meta, nil
-}
-
 func (s *huaweiCloudeyeScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	metricValue, err := s.GetCloudeyeMetrics()
 
@@ -250,15 +108,15 @@ func (s *huaweiCloudeyeScaler) GetMetricsAndActivity(_ context.Context, metricNa
 	}
 
 	metric := GenerateMetricInMili(metricName, metricValue)
-	return []external_metrics.ExternalMetricValue{metric}, metricValue > s.metadata.activationTargetMetricValue, nil
+	return []external_metrics.ExternalMetricValue{metric}, metricValue > s.metadata.ActivationTargetMetricValue, nil
 }
 
 func (s *huaweiCloudeyeScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("huawei-cloudeye-%s", s.metadata.metricsName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("huawei-cloudeye-%s", s.metadata.MetricsName))),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.targetMetricValue),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetMetricValue),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -270,14 +128,14 @@ func (s *huaweiCloudeyeScaler) Close(context.Context) error {
 
 func (s *huaweiCloudeyeScaler) GetCloudeyeMetrics() (float64, error) {
 	options := aksk.AKSKOptions{
-		IdentityEndpoint: s.metadata.huaweiAuthorization.IdentityEndpoint,
-		ProjectID:        s.metadata.huaweiAuthorization.ProjectID,
-		AccessKey:        s.metadata.huaweiAuthorization.AccessKey,
-		SecretKey:        s.metadata.huaweiAuthorization.SecretKey,
-		Region:           s.metadata.huaweiAuthorization.Region,
-		Domain:           s.metadata.huaweiAuthorization.Domain,
-		DomainID:         s.metadata.huaweiAuthorization.DomainID,
-		Cloud:            s.metadata.huaweiAuthorization.Cloud,
+		IdentityEndpoint: s.metadata.HuaweiAuthorization.IdentityEndpoint,
+		ProjectID:        s.metadata.HuaweiAuthorization.ProjectID,
+		AccessKey:        s.metadata.HuaweiAuthorization.AccessKey,
+		SecretKey:        s.metadata.HuaweiAuthorization.SecretKey,
+		Region:           s.metadata.HuaweiAuthorization.Region,
+		Domain:           s.metadata.HuaweiAuthorization.Domain,
+		DomainID:         s.metadata.HuaweiAuthorization.DomainID,
+		Cloud:            s.metadata.HuaweiAuthorization.Cloud,
 	}
 
 	provider, err := openstack.AuthenticatedClient(options)
@@ -299,20 +157,20 @@ func (s *huaweiCloudeyeScaler) GetCloudeyeMetrics() (float64, error) {
 	opts := metricdata.BatchQueryOpts{
 		Metrics: []metricdata.Metric{
 			{
-				Namespace: s.metadata.namespace,
+				Namespace: s.metadata.Namespace,
 				Dimensions: []map[string]string{
 					{
-						"name":  s.metadata.dimensionName,
-						"value": s.metadata.dimensionValue,
+						"name":  s.metadata.DimensionName,
+						"value": s.metadata.DimensionValue,
 					},
 				},
-				MetricName: s.metadata.metricsName,
+				MetricName: s.metadata.MetricsName,
 			},
 		},
-		From:   time.Now().Truncate(time.Minute).Add(time.Second*-1*time.Duration(s.metadata.metricCollectionTime)).UnixNano() / 1e6,
+		From:   time.Now().Truncate(time.Minute).Add(time.Second*-1*time.Duration(s.metadata.MetricCollectionTime)).UnixNano() / 1e6,
 		To:     time.Now().Truncate(time.Minute).UnixNano() / 1e6,
-		Period: s.metadata.metricPeriod,
-		Filter: s.metadata.metricFilter,
+		Period: strconv.Itoa(s.metadata.MetricPeriod),
+		Filter: s.metadata.MetricFilter,
 	}
 
 	metricdatas, err := metricdata.BatchQuery(sc, opts).ExtractMetricDatas()
@@ -330,7 +188,7 @@ func (s *huaweiCloudeyeScaler) GetCloudeyeMetrics() (float64, error) {
 	var metricValue float64
 
 	if metricdatas[0].Datapoints != nil && len(metricdatas[0].Datapoints) > 0 {
-		v, ok := metricdatas[0].Datapoints[0][s.metadata.metricFilter].(float64)
+		v, ok := metricdatas[0].Datapoints[0][s.metadata.MetricFilter].(float64)
 		if ok {
 			metricValue = v
 		} else {
@@ -25,6 +25,7 @@ type parseHuaweiCloudeyeMetadataTestData struct {
 	authParams map[string]string
 	isError    bool
 	comment    string
+	parity     *metadataParity
 }
 
 type huaweiCloudeyeMetricIdentifier struct {
@@ -64,7 +65,8 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		"minMetricValue":    "1"},
 		testHuaweiAuthenticationWithCloud,
 		false,
-		"auth parameter with Cloud"},
+		"auth parameter with Cloud",
+		nil},
 	{map[string]string{
 		"namespace":         "SYS.ELB",
 		"dimensionName":     "lbaas_instance_id",
@@ -74,7 +76,15 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		"minMetricValue":    "1"},
 		testHuaweiAuthenticationWithoutCloud,
 		false,
-		"auth parameter without Cloud"},
+		"auth parameter without Cloud",
+		&metadataParity{fields: map[string]any{
+			"MetricCollectionTime":          300,
+			"MetricFilter":                  "average",
+			"MetricPeriod":                  300,
+			"ActivationTargetMetricValue":   1,
+			"HuaweiAuthorization.Cloud":     "myhuaweicloud.com",
+			"HuaweiAuthorization.SecretKey": testHuaweiCloudeyeSecretKey,
+		}}},
 	{map[string]string{
 		"namespace":            "SYS.ELB",
 		"dimensionName":        "lbaas_instance_id",
@@ -87,8 +97,9 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		"metricPeriod":         "300"},
 		testHuaweiAuthenticationWithCloud,
 		false,
-		"all parameter"},
-	{map[string]string{}, testHuaweiAuthenticationWithCloud, true, "Empty structures"},
+		"all parameter",
+		nil},
+	{map[string]string{}, testHuaweiAuthenticationWithCloud, true, "Empty structures", nil},
 	{map[string]string{
 		"dimensionName":     "lbaas_instance_id",
 		"dimensionValue":    "5e052238-0346-xxb0-86ea-92d9f33e29d2",
@@ -97,7 +108,8 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		"minMetricValue":    "1"},
 		testHuaweiAuthenticationWithCloud,
 		true,
-		"metadata miss namespace"},
+		"metadata miss namespace",
+		nil},
 	{map[string]string{
 		"namespace":         "SYS.ELB",
 		"dimensionValue":    "5e052238-0346-xxb0-86ea-92d9f33e29d2",
@@ -106,7 +118,8 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		"minMetricValue":    "1"},
 		testHuaweiAuthenticationWithCloud,
 		true,
-		"metadata miss dimensionName"},
+		"metadata miss dimensionName",
+		nil},
 	{map[string]string{
 		"namespace":         "SYS.ELB",
 		"dimensionName":     "lbaas_instance_id",
@@ -115,7 +128,8 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		"minMetricValue":    "1"},
 		testHuaweiAuthenticationWithCloud,
 		true,
-		"metadata miss dimensionValue"},
+		"metadata miss dimensionValue",
+		nil},
 	{map[string]string{
 		"namespace":         "SYS.ELB",
 		"dimensionName":     "lbaas_instance_id",
@@ -124,7 +138,8 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		"minMetricValue":    "1"},
 		testHuaweiAuthenticationWithCloud,
 		true,
-		"metadata miss metricName"},
+		"metadata miss metricName",
+		nil},
 	{map[string]string{
 		"namespace":      "SYS.ELB",
 		"dimensionName":  "lbaas_instance_id",
@@ -133,7 +148,8 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		"minMetricValue": "1"},
 		testHuaweiAuthenticationWithCloud,
 		true,
-		"metadata miss targetMetricValue"},
+		"metadata miss targetMetricValue",
+		nil},
 	{map[string]string{
 		"namespace":         "SYS.ELB",
 		"dimensionName":     "lbaas_instance_id",
@@ -142,7 +158,8 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		"targetMetricValue": "100"},
 		testHuaweiAuthenticationWithCloud,
 		true,
-		"metadata miss minMetricValue"},
+		"metadata miss minMetricValue",
+		nil},
 	{map[string]string{
 		"namespace":                   "SYS.ELB",
 		"dimensionName":               "lbaas_instance_id",
@@ -152,7 +169,43 @@ var testHuaweiCloudeyeMetadata = []parseHuaweiCloudeyeMetadataTestData{
 		"activationTargetMetricValue": "aaaaa"},
 		testHuaweiAuthenticationWithCloud,
 		true,
-		"invalid activationTargetMetricValue"},
+		"invalid activationTargetMetricValue",
+		nil},
+	{map[string]string{
+		"namespace":         "SYS.ELB",
+		"dimensionName":     "lbaas_instance_id",
+		"dimensionValue":    "5e052238-0346-xxb0-86ea-92d9f33e29d2",
+		"metricName":        "mb_l7_qps",
+		"targetMetricValue": "100",
+		"minMetricValue":    "1",
+		"metricPeriod":      "0"},
+		testHuaweiAuthenticationWithCloud,
+		true,
+		"metricPeriod not positive",
+		&metadataParity{err: "is less than the minimum 1"}},
+	{map[string]string{
+		"namespace":            "SYS.ELB",
+		"dimensionName":        "lbaas_instance_id",
+		"dimensionValue":       "5e052238-0346-xxb0-86ea-92d9f33e29d2",
+		"metricName":           "mb_l7_qps",
+		"targetMetricValue":    "100",
+		"minMetricValue":       "1",
+		"metricCollectionTime": "-60"},
+		testHuaweiAuthenticationWithCloud,
+		true,
+		"metricCollectionTime not positive",
+		&metadataParity{err: "is less than the minimum 1"}},
+	{map[string]string{
+		"namespace":         "SYS.ELB",
+		"dimensionName":     "lbaas_instance_id",
+		"dimensionValue":    "5e052238-0346-xxb0-86ea-92d9f33e29d2",
+		"metricName":        "mb_l7_qps",
+		"targetMetricValue": "100",
+		"minMetricValue":    "1"},
+		map[string]string{"IdentityEndpoint": "none"},
+		true,
+		"auth parameters missing",
+		nil},
 }
 
 var huaweiCloudeyeMetricIdentifiers = []huaweiCloudeyeMetricIdentifier{
@@ -162,13 +215,14 @@ var huaweiCloudeyeMetricIdentifiers = []huaweiCloudeyeMetricIdentifier{
 
 func TestHuaweiCloudeyeParseMetadata(t *testing.T) {
 	for _, testData := range testHuaweiCloudeyeMetadata {
-		_, err := parseHuaweiCloudeyeMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams}, logr.Discard())
+		meta, err := parseHuaweiCloudeyeMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams}, logr.Discard())
 		if err != nil && !testData.isError {
 			t.Errorf("%s: Expected success but got error %s", testData.comment, err)
 		}
 		if testData.isError && err == nil {
 			t.Errorf("%s: Expected error but got success", testData.comment)
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
 	}
 }
 
@@ -4,11 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -19,11 +20,8 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
-// Default variables and settings
-const (
-	defaultTargetQueueDepth = 20
-	defaultTLSDisabled      = false
-)
+// ibmmqAdminPathTemplate is the path of the MQSC admin REST endpoint of the queue manager
+const ibmmqAdminPathTemplate = "/ibmmq/rest/v2/admin/action/qmgr/%s/mqsc"
 
 // IBMMQScaler assigns struct data pointer to metadata variable
 type IBMMQScaler struct {
@@ -35,15 +33,16 @@ type IBMMQScaler struct {
 
 // IBMMQMetadata Metadata used by KEDA to query IBM MQ queue depth and scale
 type IBMMQMetadata struct {
-	host                 string
-	queueManager         string
-	queueName            string
-	username             string
-	password             string
-	queueDepth           int64
-	activationQueueDepth int64
-	tlsDisabled          bool
-	triggerIndex         int
+	Host                 string `keda:"name=host,                 order=triggerMetadata"`
+	QueueManager         string `keda:"name=queueManager,         order=triggerMetadata"`
+	QueueName            string `keda:"name=queueName,            order=triggerMetadata"`
+	Username             string `keda:"name=username,             order=authParams;resolvedEnv"`
+	Password             string `keda:"name=password,             order=authParams;resolvedEnv, sensitive"`
+	QueueDepth           int64  `keda:"name=queueDepth,           order=triggerMetadata, default=20"`
+	ActivationQueueDepth int64  `keda:"name=activationQueueDepth, order=triggerMetadata, default=0"`
+	TLSDisabled          bool   `keda:"name=tls,                  order=triggerMetadata, default=false"`
+
+	triggerIndex int
 }
 
 // CommandResponse Full structured response from MQ admin REST query
@@ -61,6 +60,15 @@ type Parameters struct {
 	Curdepth int `json:"curdepth"`
 }
 
+// ibmmqCommandRequest is the MQSC command sent to the MQ admin REST endpoint
+type ibmmqCommandRequest struct {
+	Type               string   `json:"type"`
+	Command            string   `json:"command"`
+	Qualifier          string   `json:"qualifier"`
+	Name               string   `json:"name"`
+	ResponseParameters []string `json:"responseParameters"`
+}
+
 // NewIBMMQScaler creates a new IBM MQ scaler
 func NewIBMMQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -68,7 +76,9 @@ func NewIBMMQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
 	}
 
-	meta, err := parseIBMMQMetadata(config)
+	logger := InitializeLogger(config, "ibm_mq_scaler")
+
+	meta, err := parseIBMMQMetadata(config, logger)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing IBM MQ metadata: %w", err)
 	}
@@ -77,7 +87,7 @@ func NewIBMMQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		metricType:         metricType,
 		metadata:           meta,
 		defaultHTTPTimeout: config.GlobalHTTPTimeout,
-		logger:             InitializeLogger(config, "ibm_mq_scaler"),
+		logger:             logger,
 	}, nil
 }
 
@@ -86,99 +96,73 @@ func (s *IBMMQScaler) Close(context.Context) error {
 	return nil
 }
 
-// parseIBMMQMetadata checks the existence of and validates the MQ connection data provided
-func parseIBMMQMetadata(config *scalersconfig.ScalerConfig) (*IBMMQMetadata, error) {
-	meta := IBMMQMetadata{}
-
-	if val, ok := config.TriggerMetadata["host"]; ok {
-		_, err := url.ParseRequestURI(val)
-		if err != nil {
-			return nil, fmt.Errorf("invalid URL: %w", err)
+// Validate checks the host is a valid MQ admin REST URL
+func (m *IBMMQMetadata) Validate() error {
+	if _, err := url.ParseRequestURI(m.Host); err != nil {
+		// the url.Error would include the host, which may contain credentials
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
 		}
-		meta.host = val
-	} else {
-		return nil, fmt.Errorf("no host URI given")
+		return fmt.Errorf("invalid URL: %w", err)
 	}
+	return nil
+}
 
-	if val, ok := config.TriggerMetadata["queueManager"]; ok {
-		meta.queueManager = val
-	} else {
-		return nil, fmt.Errorf("no queue manager given")
-	}
+// hasAdminPath returns true for the legacy host with the whole admin endpoint path, which includes
+// the queue manager
+func (m *IBMMQMetadata) hasAdminPath() bool {
+	u, err := url.Parse(m.Host)
+	return err == nil && strings.Trim(u.Path, "/") != ""
+}
 
-	if val, ok := config.TriggerMetadata["queueName"]; ok {
-		meta.queueName = val
-	} else {
-		return nil, fmt.Errorf("no queue name given")
-	}
-
-	if val, ok := config.TriggerMetadata["queueDepth"]; ok && val != "" {
-		queueDepth, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("invalid queueDepth - must be an integer")
-		}
-		meta.queueDepth = queueDepth
-	} else {
-		fmt.Println("No target depth defined - setting default")
-		meta.queueDepth = defaultTargetQueueDepth
+// adminEndpoint returns the URL of the MQSC admin REST endpoint, the path with the queue manager is
+// appended to the host unless it's already there
+func (m *IBMMQMetadata) adminEndpoint() string {
+	if m.hasAdminPath() {
+		return m.Host
 	}
+	return strings.TrimSuffix(m.Host, "/") + fmt.Sprintf(ibmmqAdminPathTemplate, url.PathEscape(m.QueueManager))
+}
 
-	meta.activationQueueDepth = 0
-	if val, ok := config.TriggerMetadata["activationQueueDepth"]; ok && val != "" {
-		activationQueueDepth, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("invalid activationQueueDepth - must be an integer")
-		}
-		meta.activationQueueDepth = activationQueueDepth
-	}
+// queueDepthRequest returns the MQSC command querying the current depth of the queue
+func (m *IBMMQMetadata) queueDepthRequest() ([]byte, error) {
+	return json.Marshal(ibmmqCommandRequest{
+		Type:               "runCommandJSON",
+		Command:            "display",
+		Qualifier:          "qlocal",
+		Name:               m.QueueName,
+		ResponseParameters: []string{"CURDEPTH"},
+	})
+}
 
-	if val, ok := config.TriggerMetadata["tls"]; ok {
-		tlsDisabled, err := strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("invalid tls setting: %w", err)
-		}
-		meta.tlsDisabled = tlsDisabled
-	} else {
-		fmt.Println("No tls setting defined - setting default")
-		meta.tlsDisabled = defaultTLSDisabled
-	}
-	val, ok := config.AuthParams["username"]
-	switch {
-	case ok && val != "":
-		meta.username = val
-	case config.TriggerMetadata["usernameFromEnv"] != "":
-		meta.username = config.ResolvedEnv[config.TriggerMetadata["usernameFromEnv"]]
-	default:
-		return nil, fmt.Errorf("no username given")
+// parseIBMMQMetadata checks the existence of and validates the MQ connection data provided
+func parseIBMMQMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*IBMMQMetadata, error) {
+	meta := IBMMQMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, err
 	}
-	pwdValue, booleanValue := config.AuthParams["password"] // booleanValue reports whether the type assertion succeeded or not
-	switch {
-	case booleanValue && pwdValue != "":
-		meta.password = pwdValue
-	case config.TriggerMetadata["passwordFromEnv"] != "":
-		meta.password = config.ResolvedEnv[config.TriggerMetadata["passwordFromEnv"]]
-	default:
-		return nil, fmt.Errorf("no password given")
+	if meta.hasAdminPath() {
+		logger.Info("host with the admin endpoint path is deprecated, use the MQ REST base URL, the path is built from queueManager")
 	}
-	meta.triggerIndex = config.TriggerIndex
 	return &meta, nil
 }
 
 // getQueueDepthViaHTTP returns the depth of the MQ Queue from the Admin endpoint
 func (s *IBMMQScaler) getQueueDepthViaHTTP(ctx context.Context) (int64, error) {
-	queue := s.metadata.queueName
-	url := s.metadata.host
-
-	var requestJSON = []byte(`{"type": "runCommandJSON", "command": "display", "qualifier": "qlocal", "name": "` + queue + `", "responseParameters" : ["CURDEPTH"]}`)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestJSON))
+	body, err := s.metadata.queueDepthRequest()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build queue depth request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.metadata.adminEndpoint(), bytes.NewBuffer(body))
 	if err != nil {
 		return 0, fmt.Errorf("failed to request queue depth: %w", err)
 	}
 	req.Header.Set("ibm-mq-rest-csrf-token", "value")
 	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
 
-	client := kedautil.CreateHTTPClient(s.defaultHTTPTimeout, s.metadata.tlsDisabled)
+	client := kedautil.CreateHTTPClient(s.defaultHTTPTimeout, s.metadata.TLSDisabled)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -186,7 +170,7 @@ func (s *IBMMQScaler) getQueueDepthViaHTTP(ctx context.Context) (int64, error) {
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err = io.ReadAll(resp.Body)
 	if err != nil {
 		return 0, fmt.Errorf("failed to ready body of request: %w", err)
 	}
@@ -207,9 +191,9 @@ func (s *IBMMQScaler) getQueueDepthViaHTTP(ctx context.Context) (int64, error) {
 func (s *IBMMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("ibmmq-%s", s.metadata.queueName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("ibmmq-%s", s.metadata.QueueName))),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.queueDepth),
+		Target: GetMetricTarget(s.metricType, s.metadata.QueueDepth),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -224,5 +208,5 @@ func (s *IBMMQScaler) GetMetricsAndActivity(ctx context.Context, metricName stri
 
 	metric := GenerateMetricInMili(metricName, float64(queueDepth))
 
-	return []external_metrics.ExternalMetricValue{metric}, queueDepth > s.metadata.activationQueueDepth, nil
+	return []external_metrics.ExternalMetricValue{metric}, queueDepth > s.metadata.ActivationQueueDepth, nil
 }
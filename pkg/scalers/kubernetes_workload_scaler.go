@@ -3,7 +3,6 @@ package scalers
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -25,9 +24,6 @@ type kubernetesWorkloadScaler struct {
 
 const (
 	kubernetesWorkloadMetricType = "External"
-	podSelectorKey               = "podSelector"
-	valueKey                     = "value"
-	activationValueKey           = "activationValue"
 )
 
 var phasesCountedAsTerminated = []corev1.PodPhase{
@@ -36,11 +32,24 @@ var phasesCountedAsTerminated = []corev1.PodPhase{
 }
 
 type kubernetesWorkloadMetadata struct {
-	podSelector     labels.Selector
-	namespace       string
-	value           float64
-	activationValue float64
-	triggerIndex    int
+	PodSelector     labels.Selector `keda:"name=podSelector,     order=triggerMetadata"`
+	Value           float64         `keda:"name=value,           order=triggerMetadata, optional"`
+	ActivationValue float64         `keda:"name=activationValue, order=triggerMetadata, default=0"`
+
+	namespace      string
+	asMetricSource bool
+	triggerIndex   int
+}
+
+func (m *kubernetesWorkloadMetadata) Validate() error {
+	// the selector is nil only if it failed to parse, that error is already reported
+	if m.PodSelector != nil && m.PodSelector.Empty() {
+		return fmt.Errorf("invalid pod selector")
+	}
+	if m.Value <= 0 && !m.asMetricSource {
+		return fmt.Errorf("value must be a float greater than 0")
+	}
+	return nil
 }
 
 // NewKubernetesWorkloadScaler creates a new kubernetesWorkloadScaler
@@ -64,33 +73,11 @@ func NewKubernetesWorkloadScaler(kubeClient client.Client, config *scalersconfig
 }
 
 func parseWorkloadMetadata(config *scalersconfig.ScalerConfig) (*kubernetesWorkloadMetadata, error) {
-	meta := &kubernetesWorkloadMetadata{}
-	var err error
-	meta.namespace = config.ScalableObjectNamespace
-	podSelector, err := labels.Parse(config.TriggerMetadata[podSelectorKey])
-	if err != nil || podSelector.String() == "" {
-		return nil, fmt.Errorf("invalid pod selector")
+	meta := &kubernetesWorkloadMetadata{asMetricSource: config.AsMetricSource}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
 	}
-	meta.podSelector = podSelector
-	value, err := strconv.ParseFloat(config.TriggerMetadata[valueKey], 64)
-	if err != nil || value == 0 {
-		if config.AsMetricSource {
-			value = 0
-		} else {
-			return nil, fmt.Errorf("value must be a float greater than 0")
-		}
-	}
-	meta.value = value
-
-	meta.activationValue = 0
-	if val, ok := config.TriggerMetadata[activationValueKey]; ok {
-		activationValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("value must be a float")
-		}
-		meta.activationValue = activationValue
-	}
-
+	meta.namespace = config.ScalableObjectNamespace
 	meta.triggerIndex = config.TriggerIndex
 	return meta, nil
 }
@@ -106,7 +93,7 @@ func (s *kubernetesWorkloadScaler) GetMetricSpecForScaling(context.Context) []v2
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("workload-%s", s.metadata.namespace))),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: kubernetesWorkloadMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -121,13 +108,13 @@ func (s *kubernetesWorkloadScaler) GetMetricsAndActivity(ctx context.Context, me
 
 	metric := GenerateMetricInMili(metricName, float64(pods))
 
-	return []external_metrics.ExternalMetricValue{metric}, float64(pods) > s.metadata.activationValue, nil
+	return []external_metrics.ExternalMetricValue{metric}, float64(pods) > s.metadata.ActivationValue, nil
 }
 
 func (s *kubernetesWorkloadScaler) getMetricValue(ctx context.Context) (int64, error) {
 	podList := &corev1.PodList{}
 	listOptions := client.ListOptions{}
-	listOptions.LabelSelector = s.metadata.podSelector
+	listOptions.LabelSelector = s.metadata.PodSelector
 	listOptions.Namespace = s.metadata.namespace
 	opts := []client.ListOption{
 		&listOptions,
@@ -15,36 +15,44 @@ import (
 )
 
 type workloadMetadataTestData struct {
-	metadata  map[string]string
-	namespace string
-	isError   bool
+	metadata       map[string]string
+	namespace      string
+	asMetricSource bool
+	isError        bool
+	parity         *metadataParity
 }
 
 var parseWorkloadMetadataTestDataset = []workloadMetadataTestData{
-	{map[string]string{"value": "1", "podSelector": "app=demo"}, "test", false},
-	{map[string]string{"value": "1", "podSelector": "app=demo"}, "default", false},
-	{map[string]string{"value": "1", "podSelector": "app in (demo1, demo2)"}, "test", false},
-	{map[string]string{"value": "1", "podSelector": "app in (demo1, demo2),deploy in (deploy1, deploy2)"}, "test", false},
-	{map[string]string{"podSelector": "app=demo"}, "test", true},
-	{map[string]string{"podSelector": "app=demo"}, "default", true},
-	{map[string]string{"value": "1"}, "test", true},
-	{map[string]string{"value": "1"}, "default", true},
-	{map[string]string{"value": "a", "podSelector": "app=demo"}, "test", true},
-	{map[string]string{"value": "a", "podSelector": "app=demo"}, "default", true},
-	{map[string]string{"value": "0", "podSelector": "app=demo"}, "test", true},
-	{map[string]string{"value": "0", "podSelector": "app=demo"}, "default", true},
-	{map[string]string{"value": "1", "activationValue": "aa", "podSelector": "app=demo"}, "test", true},
+	{map[string]string{"value": "1", "podSelector": "app=demo"}, "test", false, false, nil},
+	{map[string]string{"value": "1", "podSelector": "app=demo"}, "default", false, false, nil},
+	{map[string]string{"value": "1", "podSelector": "app in (demo1, demo2)"}, "test", false, false, nil},
+	{map[string]string{"value": "1", "podSelector": "app in (demo1, demo2),deploy in (deploy1, deploy2)"}, "test", false, false, nil},
+	{map[string]string{"podSelector": "app=demo"}, "test", false, true, nil},
+	{map[string]string{"podSelector": "app=demo"}, "default", false, true, nil},
+	{map[string]string{"value": "1"}, "test", false, true, nil},
+	{map[string]string{"value": "1"}, "default", false, true, nil},
+	{map[string]string{"value": "a", "podSelector": "app=demo"}, "test", false, true, nil},
+	{map[string]string{"value": "a", "podSelector": "app=demo"}, "default", false, true, nil},
+	{map[string]string{"value": "0", "podSelector": "app=demo"}, "test", false, true, nil},
+	{map[string]string{"value": "0", "podSelector": "app=demo"}, "default", false, true, nil},
+	{map[string]string{"value": "1", "activationValue": "aa", "podSelector": "app=demo"}, "test", false, true, nil},
+	{map[string]string{"value": "1", "podSelector": "app in (demo1"}, "test", false, true, &metadataParity{err: `value "app in (demo1": expected label selector`}},
+	{map[string]string{"value": "1", "podSelector": "  "}, "test", false, true, nil},
+	{map[string]string{"value": "-1", "podSelector": "app=demo"}, "test", false, true, nil},
+	{map[string]string{"podSelector": "app=demo"}, "test", true, false, nil},
+	{map[string]string{"value": "2.5", "activationValue": "1", "podSelector": "app in (demo1, demo2)"}, "test", false, false, &metadataParity{fields: map[string]any{"PodSelector": "app in (demo1,demo2)", "Value": 2.5, "ActivationValue": 1, "namespace": "test"}}},
 }
 
 func TestParseWorkloadMetadata(t *testing.T) {
 	for _, testData := range parseWorkloadMetadataTestDataset {
-		_, err := parseWorkloadMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: testData.namespace})
+		meta, err := parseWorkloadMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: testData.namespace, AsMetricSource: testData.asMetricSource})
 		if err != nil && !testData.isError {
 			t.Error("Expected success but got error", err)
 		}
 		if testData.isError && err == nil {
 			t.Error("Expected error but got success")
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 	neturl "net/url"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/go-logr/logr"
 	"github.com/tidwall/gjson"
@@ -33,40 +34,109 @@ type metricsAPIScaler struct {
 }
 
 type metricsAPIScalerMetadata struct {
-	targetValue           float64
-	activationTargetValue float64
-	url                   string
-	format                APIFormat
-	valueLocation         string
-	unsafeSsl             bool
+	TargetValue           *float64  `keda:"name=targetValue,           order=triggerMetadata, optional"`
+	ActivationTargetValue float64   `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+	URL                   string    `keda:"name=url,                   order=triggerMetadata"`
+	Format                APIFormat `keda:"name=format,                order=triggerMetadata, default=json"`
+	ValueLocation         string    `keda:"name=valueLocation,         order=triggerMetadata"`
+	UnsafeSsl             bool      `keda:"name=unsafeSsl,             order=triggerMetadata, default=false"`
+
+	AuthMode authentication.Type `keda:"name=authMode, order=triggerMetadata, optional"`
 
 	// apiKeyAuth
-	enableAPIKeyAuth bool
-	method           string // way of providing auth key, either "header" (default) or "query"
+	Method string `keda:"name=method, order=triggerMetadata, default=header"` // way of providing auth key, either "header" (default) or "query"
 	// keyParamName  is either header key or query param used for passing apikey
 	// default header is "X-API-KEY", defaul query param is "api_key"
-	keyParamName string
-	apiKey       string
+	KeyParamName string `keda:"name=keyParamName, order=triggerMetadata, optional"`
+	APIKey       string `keda:"name=apiKey,       order=authParams,      optional"`
 
 	// base auth
-	enableBaseAuth bool
-	username       string
-	password       string // +optional
+	Username string `keda:"name=username, order=authParams, optional"`
+	Password string `keda:"name=password, order=authParams, optional"` // +optional
 
 	// client certification
-	enableTLS bool
-	cert      string
-	key       string
-	ca        string
+	Cert string `keda:"name=cert, order=authParams, optional"`
+	Key  string `keda:"name=key,  order=authParams, optional"`
+	CA   string `keda:"name=ca,   order=authParams, optional"`
 
 	// bearer
+	BearerToken string `keda:"name=token, order=authParams, optional"`
+
+	enableAPIKeyAuth bool
+	enableBaseAuth   bool
+	enableTLS        bool
 	enableBearerAuth bool
-	bearerToken      string
 
-	triggerIndex int
+	triggerIndex   int
+	asMetricSource bool
+}
+
+func (m *metricsAPIScalerMetadata) Validate() error {
+	if m.TargetValue == nil && !m.asMetricSource {
+		return fmt.Errorf("no targetValue given in metadata")
+	}
+	if !kedautil.Contains(supportedFormats, m.Format) {
+		return fmt.Errorf("format %s not supported", m.Format)
+	}
+	if err := validateValueLocation(m.ValueLocation, m.Format); err != nil {
+		return err
+	}
+
+	switch m.AuthMode {
+	case "":
+	case authentication.APIKeyAuthType:
+		if m.APIKey == "" {
+			return errors.New("no apikey provided")
+		}
+		if m.Method != methodValueHeader && m.Method != methodValueQuery {
+			return fmt.Errorf("method %s not supported, has to be one of [%s %s]", m.Method, methodValueHeader, methodValueQuery)
+		}
+	case authentication.BasicAuthType:
+		// password is optional. For convenience, many application implements basic auth with
+		// username as apikey and password as empty
+		if m.Username == "" {
+			return errors.New("no username given")
+		}
+	case authentication.TLSAuthType:
+		if m.CA == "" {
+			return errors.New("no ca given")
+		}
+		if m.Cert == "" {
+			return errors.New("no cert given")
+		}
+		if m.Key == "" {
+			return errors.New("no key given")
+		}
+	case authentication.BearerAuthType:
+		if m.BearerToken == "" {
+			return errors.New("no token provided")
+		}
+	default:
+		return fmt.Errorf("err incorrect value for authMode is given: %s", m.AuthMode)
+	}
+	return nil
+}
+
+// validateValueLocation checks the valueLocation can be looked up in the response of the given format,
+// json uses the GJSON syntax, xml and yaml use dot separated keys and prometheus matches the metric name prefix
+func validateValueLocation(valueLocation string, format APIFormat) error {
+	switch format {
+	case XMLFormat, YAMLFormat:
+		for _, key := range strings.Split(valueLocation, ".") {
+			if key == "" {
+				return fmt.Errorf("valueLocation %q for format %s must be dot separated keys without empty keys", valueLocation, format)
+			}
+		}
+	case PrometheusFormat:
+		if strings.ContainsFunc(valueLocation, unicode.IsSpace) {
+			return fmt.Errorf("valueLocation %q for format %s must not contain whitespace", valueLocation, format)
+		}
+	}
+	return nil
 }
 
 const (
+	methodValueHeader          = "header"
 	methodValueQuery           = "query"
 	valueLocationWrongErrorMsg = "valueLocation must point to value of type number or a string representing a Quantity got: '%s'"
 )
@@ -102,10 +172,15 @@ func NewMetricsAPIScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing metric API metadata: %w", err)
 	}
 
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
 
-	if meta.enableTLS || len(meta.ca) > 0 {
-		config, err := kedautil.NewTLSConfig(meta.cert, meta.key, meta.ca, meta.unsafeSsl)
+	if meta.enableTLS || len(meta.CA) > 0 {
+		// the client certificate is only used with the tls authMode, the ca is used with any authMode
+		cert, key := "", ""
+		if meta.enableTLS {
+			cert, key = meta.Cert, meta.Key
+		}
+		config, err := kedautil.NewTLSConfig(cert, key, meta.CA, meta.UnsafeSsl)
 		if err != nil {
 			return nil, err
 		}
@@ -120,129 +195,32 @@ func NewMetricsAPIScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	}, nil
 }
 
-func parseMetricsAPIMetadata(config *scalersconfig.ScalerConfig) (*metricsAPIScalerMetadata, error) {
-	meta := metricsAPIScalerMetadata{}
-	meta.triggerIndex = config.TriggerIndex
-
-	meta.unsafeSsl = false
-	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
-		unsafeSsl, err := strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing unsafeSsl: %w", err)
-		}
-		meta.unsafeSsl = unsafeSsl
-	}
-
-	if val, ok := config.TriggerMetadata["targetValue"]; ok {
-		targetValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("targetValue parsing error %w", err)
-		}
-		meta.targetValue = targetValue
-	} else {
-		if config.AsMetricSource {
-			meta.targetValue = 0
-		} else {
-			return nil, fmt.Errorf("no targetValue given in metadata")
-		}
-	}
-
-	meta.activationTargetValue = 0
-	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok {
-		activationTargetValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("targetValue parsing error %w", err)
-		}
-		meta.activationTargetValue = activationTargetValue
-	}
-
-	if val, ok := config.TriggerMetadata["url"]; ok {
-		meta.url = val
-	} else {
-		return nil, fmt.Errorf("no url given in metadata")
-	}
-
-	if val, ok := config.TriggerMetadata["format"]; ok {
-		meta.format = APIFormat(strings.TrimSpace(val))
-		if !kedautil.Contains(supportedFormats, meta.format) {
-			return nil, fmt.Errorf("format %s not supported", meta.format)
-		}
-	} else {
-		// default format is JSON for backward compatibility
-		meta.format = JSONFormat
-	}
-
-	if val, ok := config.TriggerMetadata["valueLocation"]; ok {
-		meta.valueLocation = val
-	} else {
-		return nil, fmt.Errorf("no valueLocation given in metadata")
+// targetValue returns the targetValue, it's only unset when the scaler is used as a metric source
+func (m *metricsAPIScalerMetadata) targetValue() float64 {
+	if m.TargetValue == nil {
+		return 0
 	}
+	return *m.TargetValue
+}
 
-	authMode, ok := config.TriggerMetadata["authMode"]
-	// no authMode specified
-	if !ok {
-		return &meta, nil
+func parseMetricsAPIMetadata(config *scalersconfig.ScalerConfig) (*metricsAPIScalerMetadata, error) {
+	meta := metricsAPIScalerMetadata{asMetricSource: config.AsMetricSource}
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, fmt.Errorf("error parsing metrics api metadata: %w", err)
 	}
 
-	authType := authentication.Type(strings.TrimSpace(authMode))
-	switch authType {
+	switch meta.AuthMode {
 	case authentication.APIKeyAuthType:
-		if len(config.AuthParams["apiKey"]) == 0 {
-			return nil, errors.New("no apikey provided")
-		}
-
-		meta.apiKey = config.AuthParams["apiKey"]
-		// default behaviour is header. only change if query param requested
-		meta.method = "header"
 		meta.enableAPIKeyAuth = true
-
-		if config.TriggerMetadata["method"] == methodValueQuery {
-			meta.method = methodValueQuery
-		}
-
-		if len(config.TriggerMetadata["keyParamName"]) > 0 {
-			meta.keyParamName = config.TriggerMetadata["keyParamName"]
-		}
 	case authentication.BasicAuthType:
-		if len(config.AuthParams["username"]) == 0 {
-			return nil, errors.New("no username given")
-		}
-
-		meta.username = config.AuthParams["username"]
-		// password is optional. For convenience, many application implements basic auth with
-		// username as apikey and password as empty
-		meta.password = config.AuthParams["password"]
 		meta.enableBaseAuth = true
 	case authentication.TLSAuthType:
-		if len(config.AuthParams["ca"]) == 0 {
-			return nil, errors.New("no ca given")
-		}
-
-		if len(config.AuthParams["cert"]) == 0 {
-			return nil, errors.New("no cert given")
-		}
-		meta.cert = config.AuthParams["cert"]
-
-		if len(config.AuthParams["key"]) == 0 {
-			return nil, errors.New("no key given")
-		}
-
-		meta.key = config.AuthParams["key"]
 		meta.enableTLS = true
 	case authentication.BearerAuthType:
-		if len(config.AuthParams["token"]) == 0 {
-			return nil, errors.New("no token provided")
-		}
-
-		meta.bearerToken = config.AuthParams["token"]
 		meta.enableBearerAuth = true
-	default:
-		return nil, fmt.Errorf("err incorrect value for authMode is given: %s", authMode)
 	}
 
-	if len(config.AuthParams["ca"]) > 0 {
-		meta.ca = config.AuthParams["ca"]
-	}
+	meta.triggerIndex = config.TriggerIndex
 	return &meta, nil
 }
 
@@ -387,7 +365,7 @@ func (s *metricsAPIScaler) getMetricValue(ctx context.Context) (float64, error)
 	if err != nil {
 		return 0, err
 	}
-	v, err := GetValueFromResponse(b, s.metadata.valueLocation, s.metadata.format)
+	v, err := GetValueFromResponse(b, s.metadata.ValueLocation, s.metadata.Format)
 	if err != nil {
 		return 0, err
 	}
@@ -406,9 +384,9 @@ func (s *metricsAPIScaler) Close(context.Context) error {
 func (s *metricsAPIScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("metric-api-%s", s.metadata.valueLocation))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("metric-api-%s", s.metadata.ValueLocation))),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue()),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -425,7 +403,7 @@ func (s *metricsAPIScaler) GetMetricsAndActivity(ctx context.Context, metricName
 
 	metric := GenerateMetricInMili(metricName, val)
 
-	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.activationTargetValue, nil
+	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.ActivationTargetValue, nil
 }
 
 func getMetricAPIServerRequest(ctx context.Context, meta *metricsAPIScalerMetadata) (*http.Request, error) {
@@ -434,13 +412,13 @@ func getMetricAPIServerRequest(ctx context.Context, meta *metricsAPIScalerMetada
 
 	switch {
 	case meta.enableAPIKeyAuth:
-		if meta.method == methodValueQuery {
-			url, _ := neturl.Parse(meta.url)
+		if meta.Method == methodValueQuery {
+			url, _ := neturl.Parse(meta.URL)
 			queryString := url.Query()
-			if len(meta.keyParamName) == 0 {
-				queryString.Set("api_key", meta.apiKey)
+			if len(meta.KeyParamName) == 0 {
+				queryString.Set("api_key", meta.APIKey)
 			} else {
-				queryString.Set(meta.keyParamName, meta.apiKey)
+				queryString.Set(meta.KeyParamName, meta.APIKey)
 			}
 
 			url.RawQuery = queryString.Encode()
@@ -450,32 +428,32 @@ func getMetricAPIServerRequest(ctx context.Context, meta *metricsAPIScalerMetada
 			}
 		} else {
 			// default behaviour is to use header method
-			req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
+			req, err = http.NewRequestWithContext(ctx, "GET", meta.URL, nil)
 			if err != nil {
 				return nil, err
 			}
 
-			if len(meta.keyParamName) == 0 {
-				req.Header.Add("X-API-KEY", meta.apiKey)
+			if len(meta.KeyParamName) == 0 {
+				req.Header.Add("X-API-KEY", meta.APIKey)
 			} else {
-				req.Header.Add(meta.keyParamName, meta.apiKey)
+				req.Header.Add(meta.KeyParamName, meta.APIKey)
 			}
 		}
 	case meta.enableBaseAuth:
-		req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
+		req, err = http.NewRequestWithContext(ctx, "GET", meta.URL, nil)
 		if err != nil {
 			return nil, err
 		}
 
-		req.SetBasicAuth(meta.username, meta.password)
+		req.SetBasicAuth(meta.Username, meta.Password)
 	case meta.enableBearerAuth:
-		req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
+		req, err = http.NewRequestWithContext(ctx, "GET", meta.URL, nil)
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", meta.bearerToken))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", meta.BearerToken))
 	default:
-		req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
+		req, err = http.NewRequestWithContext(ctx, "GET", meta.URL, nil)
 		if err != nil {
 			return nil, err
 		}
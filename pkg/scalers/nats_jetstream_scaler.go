@@ -8,7 +8,6 @@ import (
 	"net"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -21,7 +20,6 @@ import (
 
 const (
 	jetStreamMetricType             = "External"
-	defaultJetStreamLagThreshold    = 10
 	natsHTTPProtocol                = "http"
 	natsHTTPSProtocol               = "https"
 	jetStreamLagThresholdMetricName = "lagThreshold"
@@ -35,17 +33,34 @@ type natsJetStreamScaler struct {
 	logger     logr.Logger
 }
 
+// natsMonitoringEndpoint is the monitoring endpoint configuration shared by the NATS JetStream and NATS Streaming scalers
+type natsMonitoringEndpoint struct {
+	NATSServerMonitoringEndpoint string `keda:"name=natsServerMonitoringEndpoint, order=authParams;triggerMetadata"`
+	UseHTTPS                     bool   `keda:"name=useHttps,                     order=triggerMetadata, default=false"`
+}
+
 type natsJetStreamMetadata struct {
-	account                string
-	stream                 string
-	consumer               string
-	consumerLeader         string
-	monitoringURL          string
-	monitoringLeaderURL    string
-	lagThreshold           int64
-	activationLagThreshold int64
-	clusterSize            int
-	triggerIndex           int
+	MonitoringEndpoint     natsMonitoringEndpoint `keda:""`
+	Account                string                 `keda:"name=account,                order=authParams;triggerMetadata"`
+	Stream                 string                 `keda:"name=stream,                 order=triggerMetadata"`
+	Consumer               string                 `keda:"name=consumer,               order=triggerMetadata"`
+	LagThreshold           int64                  `keda:"name=lagThreshold,           order=triggerMetadata, default=10, min=1"`
+	ActivationLagThreshold int64                  `keda:"name=activationLagThreshold, order=triggerMetadata, default=0"`
+
+	consumerLeader      string
+	monitoringURL       string
+	monitoringLeaderURL string
+	clusterSize         int
+	triggerIndex        int
+}
+
+func (m *natsJetStreamMetadata) Validate() error {
+	if endpoint := m.MonitoringEndpoint.NATSServerMonitoringEndpoint; endpoint != "" {
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			return fmt.Errorf("natsServerMonitoringEndpoint %q has to be in host:port format: %w", endpoint, err)
+		}
+	}
+	return nil
 }
 
 type jetStreamEndpointResponse struct {
@@ -135,58 +150,11 @@ func NewNATSJetStreamScaler(config *scalersconfig.ScalerConfig) (Scaler, error)
 
 func parseNATSJetStreamMetadata(config *scalersconfig.ScalerConfig) (natsJetStreamMetadata, error) {
 	meta := natsJetStreamMetadata{}
-
-	account, err := GetFromAuthOrMeta(config, "account")
-	if err != nil {
+	if err := config.TypedConfig(&meta); err != nil {
 		return meta, err
 	}
-	meta.account = account
-
-	if config.TriggerMetadata["stream"] == "" {
-		return meta, errors.New("no stream name given")
-	}
-	meta.stream = config.TriggerMetadata["stream"]
-
-	if config.TriggerMetadata["consumer"] == "" {
-		return meta, errors.New("no consumer name given")
-	}
-	meta.consumer = config.TriggerMetadata["consumer"]
-
-	meta.lagThreshold = defaultJetStreamLagThreshold
-
-	if val, ok := config.TriggerMetadata[jetStreamLagThresholdMetricName]; ok {
-		t, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing %s: %w", jetStreamLagThresholdMetricName, err)
-		}
-
-		meta.lagThreshold = t
-	}
-
-	meta.activationLagThreshold = 0
-	if val, ok := config.TriggerMetadata["activationLagThreshold"]; ok {
-		activationTargetQueryValue, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("activationLagThreshold parsing error %w", err)
-		}
-		meta.activationLagThreshold = activationTargetQueryValue
-	}
-
 	meta.triggerIndex = config.TriggerIndex
-
-	natsServerEndpoint, err := GetFromAuthOrMeta(config, "natsServerMonitoringEndpoint")
-	if err != nil {
-		return meta, err
-	}
-	useHTTPS := false
-	if val, ok := config.TriggerMetadata["useHttps"]; ok {
-		useHTTPS, err = strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("useHTTPS parsing error %w", err)
-		}
-	}
-	meta.monitoringURL = getNATSJetStreamMonitoringURL(useHTTPS, natsServerEndpoint, meta.account)
-
+	meta.monitoringURL = getNATSJetStreamMonitoringURL(meta.MonitoringEndpoint.UseHTTPS, meta.MonitoringEndpoint.NATSServerMonitoringEndpoint, meta.Account)
 	return meta, nil
 }
 
@@ -292,11 +260,11 @@ func (s *natsJetStreamScaler) getNATSJetstreamMonitoringData(ctx context.Context
 			}
 
 			for _, jetStreamAccount := range jetStreamAccountResp.Accounts {
-				if jetStreamAccount.Name == s.metadata.account {
+				if jetStreamAccount.Name == s.metadata.Account {
 					for _, stream := range jetStreamAccount.Streams {
-						if stream.Name == s.metadata.stream {
+						if stream.Name == s.metadata.Stream {
 							for _, consumer := range stream.Consumers {
-								if consumer.Name == s.metadata.consumer {
+								if consumer.Name == s.metadata.Consumer {
 									// this node is the consumer leader
 									if node == consumer.Cluster.Leader {
 										s.setNATSJetStreamMonitoringData(jetStreamAccountResp, natsJetStreamMonitoringNodeURL)
@@ -309,7 +277,7 @@ func (s *natsJetStreamScaler) getNATSJetstreamMonitoringData(ctx context.Context
 				}
 			}
 		}
-		return fmt.Errorf("leader node not found for consumer %s", s.metadata.consumer)
+		return fmt.Errorf("leader node not found for consumer %s", s.metadata.Consumer)
 	}
 	return nil
 }
@@ -319,13 +287,13 @@ func (s *natsJetStreamScaler) setNATSJetStreamMonitoringData(jetStreamAccountRes
 
 	// find and assign the stream that we are looking for.
 	for _, jsAccount := range jetStreamAccountResp.Accounts {
-		if jsAccount.Name == s.metadata.account {
+		if jsAccount.Name == s.metadata.Account {
 			for _, stream := range jsAccount.Streams {
-				if stream.Name == s.metadata.stream {
+				if stream.Name == s.metadata.Stream {
 					s.stream = stream
 
 					for _, consumer := range stream.Consumers {
-						if consumer.Name == s.metadata.consumer {
+						if consumer.Name == s.metadata.Consumer {
 							s.metadata.consumerLeader = consumer.Cluster.Leader
 							if leaderURL != "" {
 								s.metadata.monitoringLeaderURL = leaderURL
@@ -441,7 +409,7 @@ func (s *natsJetStreamScaler) getNATSJetStreamMonitoringNodeURLByNode(node strin
 }
 
 func (s *natsJetStreamScaler) getMaxMsgLag() int64 {
-	consumerName := s.metadata.consumer
+	consumerName := s.metadata.Consumer
 
 	for _, consumer := range s.stream.Consumers {
 		if consumer.Name == consumerName {
@@ -452,12 +420,12 @@ func (s *natsJetStreamScaler) getMaxMsgLag() int64 {
 }
 
 func (s *natsJetStreamScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	metricName := kedautil.NormalizeString(fmt.Sprintf("nats-jetstream-%s", s.metadata.stream))
+	metricName := kedautil.NormalizeString(fmt.Sprintf("nats-jetstream-%s", s.metadata.Stream))
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.lagThreshold),
+		Target: GetMetricTarget(s.metricType, s.metadata.LagThreshold),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric,
@@ -477,11 +445,11 @@ func (s *natsJetStreamScaler) GetMetricsAndActivity(ctx context.Context, metricN
 	}
 
 	totalLag := s.getMaxMsgLag()
-	s.logger.V(1).Info("NATS JetStream Scaler: Providing metrics based on totalLag, threshold", "totalLag", totalLag, "lagThreshold", s.metadata.lagThreshold)
+	s.logger.V(1).Info("NATS JetStream Scaler: Providing metrics based on totalLag, threshold", "totalLag", totalLag, "lagThreshold", s.metadata.LagThreshold)
 
 	metric := GenerateMetricInMili(metricName, float64(totalLag))
 
-	return []external_metrics.ExternalMetricValue{metric}, totalLag > s.metadata.activationLagThreshold, nil
+	return []external_metrics.ExternalMetricValue{metric}, totalLag > s.metadata.ActivationLagThreshold, nil
 }
 
 func (s *natsJetStreamScaler) Close(context.Context) error {
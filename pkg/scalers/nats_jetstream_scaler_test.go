@@ -20,6 +20,7 @@ type parseNATSJetStreamMetadataTestData struct {
 	metadata   map[string]string
 	authParams map[string]string
 	isError    bool
+	parity     *metadataParity
 }
 
 type parseNATSJetStreamMockResponsesTestData struct {
@@ -38,37 +39,45 @@ type natsJetStreamMetricIdentifier struct {
 
 var testNATSJetStreamMetadata = []parseNATSJetStreamMetadataTestData{
 	// All good localhost.
-	{map[string]string{"natsServerMonitoringEndpoint": "localhost:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "useHttps": "false"}, map[string]string{}, false},
+	{map[string]string{"natsServerMonitoringEndpoint": "localhost:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "useHttps": "false"}, map[string]string{}, false, nil},
 	// All good url.
-	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "useHttps": "true"}, map[string]string{}, false},
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "useHttps": "true"}, map[string]string{}, false, nil},
 	// nothing passed
-	{map[string]string{}, map[string]string{}, true},
+	{map[string]string{}, map[string]string{}, true, nil},
 	// Missing account name, should fail
-	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{}, true},
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{}, true, nil},
 	// Missing stream name, should fail
-	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "consumer": "pull_consumer"}, map[string]string{}, true},
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "consumer": "pull_consumer"}, map[string]string{}, true, nil},
 	// Missing consumer name should fail
-	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream"}, map[string]string{}, true},
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream"}, map[string]string{}, true, nil},
 	// Missing nats server monitoring endpoint, should fail
-	{map[string]string{"account": "$G", "stream": "mystream"}, map[string]string{}, true},
+	{map[string]string{"account": "$G", "stream": "mystream"}, map[string]string{}, true, nil},
 	// All good + activationLagThreshold
-	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "activationLagThreshold": "10"}, map[string]string{}, false},
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "activationLagThreshold": "10"}, map[string]string{}, false, nil},
 	// Misconfigured activationLagThreshold
-	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "activationLagThreshold": "Y"}, map[string]string{}, true},
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "activationLagThreshold": "Y"}, map[string]string{}, true, nil},
 	// natsServerMonitoringEndpoint is defined in authParams
-	{map[string]string{"account": "$G", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, false},
+	{map[string]string{"account": "$G", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, false, nil},
 	// Missing nats server monitoring endpoint , should fail
-	{map[string]string{"account": "$G", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": ""}, true},
+	{map[string]string{"account": "$G", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": ""}, true, nil},
 	// Misconfigured https, should fail
-	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "useHttps": "error"}, map[string]string{}, true},
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "useHttps": "error"}, map[string]string{}, true, nil},
 	// All good + lagThreshold
-	{map[string]string{"account": "$G", "stream": "mystream", "consumer": "pull_consumer", jetStreamLagThresholdMetricName: "6"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, false},
+	{map[string]string{"account": "$G", "stream": "mystream", "consumer": "pull_consumer", jetStreamLagThresholdMetricName: "6"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, false, nil},
 	// Misconfigured lag threshold
-	{map[string]string{"account": "$G", "stream": "mystream", "consumer": "pull_consumer", jetStreamLagThresholdMetricName: "Y"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, true},
+	{map[string]string{"account": "$G", "stream": "mystream", "consumer": "pull_consumer", jetStreamLagThresholdMetricName: "Y"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, true, nil},
 	// All good + account from authParams
-	{map[string]string{"stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"account": "$G", "natsServerMonitoringEndpoint": "nats.nats:8222"}, false},
+	{map[string]string{"stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"account": "$G", "natsServerMonitoringEndpoint": "nats.nats:8222"}, false, nil},
 	// Misconfigured account
-	{map[string]string{"stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, true},
+	{map[string]string{"stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, true, nil},
+	// nats server monitoring endpoint without port, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats", "account": "$G", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{}, true, nil},
+	// lag threshold below 1, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", jetStreamLagThresholdMetricName: "0"}, map[string]string{}, true, &metadataParity{err: "is less than the minimum 1"}},
+	// lagThreshold default and http monitoring URL
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{}, false, &metadataParity{fields: map[string]any{"Account": "$G", "LagThreshold": 10, "MonitoringEndpoint.UseHTTPS": false, "monitoringURL": "http://nats.nats:8222/jsz?acc=$G&consumers=true&config=true"}}},
+	// account from authParams and https monitoring URL
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "stream": "mystream", "consumer": "pull_consumer", "useHttps": "true"}, map[string]string{"account": "$G"}, false, &metadataParity{fields: map[string]any{"MonitoringEndpoint.UseHTTPS": true, "monitoringURL": "https://nats.nats:8222/jsz?acc=$G&consumers=true&config=true"}}},
 }
 
 var natsJetStreamMetricIdentifiers = []natsJetStreamMetricIdentifier{
@@ -78,12 +87,13 @@ var natsJetStreamMetricIdentifiers = []natsJetStreamMetricIdentifier{
 
 func TestNATSJetStreamParseMetadata(t *testing.T) {
 	for _, testData := range testNATSJetStreamMetadata {
-		_, err := parseNATSJetStreamMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		meta, err := parseNATSJetStreamMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
 		if err != nil && !testData.isError {
 			t.Error("Expected success but got error", err)
 		} else if testData.isError && err == nil {
 			t.Error("Expected error but got success" + testData.authParams["natsServerMonitoringEndpoint"] + "foo")
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
 	}
 }
 
@@ -127,7 +137,7 @@ var testNATSJetStreamMockResponses = []parseNATSJetStreamMockResponsesTestData{
 		"All Good - no messages waiting (not active)",
 		&natsJetStreamMetricIdentifier{
 			&parseNATSJetStreamMetadataTestData{
-				testNATSJetStreamGoodMetadata, map[string]string{}, false},
+				testNATSJetStreamGoodMetadata, map[string]string{}, false, nil},
 			0, "s0-nats-jetstream-mystream",
 		},
 		&jetStreamEndpointResponse{
@@ -141,7 +151,7 @@ var testNATSJetStreamMockResponses = []parseNATSJetStreamMockResponsesTestData{
 		"All Good - messages waiting (active)",
 		&natsJetStreamMetricIdentifier{
 			&parseNATSJetStreamMetadataTestData{
-				testNATSJetStreamGoodMetadata, map[string]string{}, false},
+				testNATSJetStreamGoodMetadata, map[string]string{}, false, nil},
 			0, "s0-nats-jetstream-mystream",
 		},
 		&jetStreamEndpointResponse{
@@ -155,7 +165,7 @@ var testNATSJetStreamMockResponses = []parseNATSJetStreamMockResponsesTestData{
 		"Not Active - Bad consumer name uses stream last sequence",
 		&natsJetStreamMetricIdentifier{
 			&parseNATSJetStreamMetadataTestData{
-				testNATSJetStreamGoodMetadata, map[string]string{}, false},
+				testNATSJetStreamGoodMetadata, map[string]string{}, false, nil},
 			0, "s0-nats-jetstream-mystream",
 		},
 		&jetStreamEndpointResponse{
@@ -169,7 +179,7 @@ var testNATSJetStreamMockResponses = []parseNATSJetStreamMockResponsesTestData{
 		"Fail - Non-matching stream name",
 		&natsJetStreamMetricIdentifier{
 			&parseNATSJetStreamMetadataTestData{
-				testNATSJetStreamGoodMetadata, map[string]string{}, false},
+				testNATSJetStreamGoodMetadata, map[string]string{}, false, nil},
 			0, "s0-nats-jetstream-mystream",
 		},
 		&jetStreamEndpointResponse{
@@ -183,7 +193,7 @@ var testNATSJetStreamMockResponses = []parseNATSJetStreamMockResponsesTestData{
 		"Fail - Unresolvable nats endpoint from config",
 		&natsJetStreamMetricIdentifier{
 			&parseNATSJetStreamMetadataTestData{
-				map[string]string{"natsServerMonitoringEndpoint": "asdf32423fdsafdasdf:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "activationLagThreshold": "10"}, map[string]string{}, false},
+				map[string]string{"natsServerMonitoringEndpoint": "asdf32423fdsafdasdf:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "activationLagThreshold": "10"}, map[string]string{}, false, nil},
 			0, "s0-nats-jetstream-mystream",
 		},
 		&jetStreamEndpointResponse{
@@ -197,7 +207,7 @@ var testNATSJetStreamMockResponses = []parseNATSJetStreamMockResponsesTestData{
 		"All Good - messages waiting (clustered)",
 		&natsJetStreamMetricIdentifier{
 			&parseNATSJetStreamMetadataTestData{
-				testNATSJetStreamGoodMetadata, map[string]string{}, false},
+				testNATSJetStreamGoodMetadata, map[string]string{}, false, nil},
 			0, "s0-nats-jetstream-mystream",
 		},
 		&jetStreamEndpointResponse{
@@ -212,7 +222,7 @@ var testNATSJetStreamMockResponses = []parseNATSJetStreamMockResponsesTestData{
 		"Not Active - consumer missing - connected to node without consumer info (clustered)",
 		&natsJetStreamMetricIdentifier{
 			&parseNATSJetStreamMetadataTestData{
-				testNATSJetStreamGoodMetadata, map[string]string{}, false},
+				testNATSJetStreamGoodMetadata, map[string]string{}, false, nil},
 			0, "s0-nats-jetstream-mystream",
 		},
 		&jetStreamEndpointResponse{
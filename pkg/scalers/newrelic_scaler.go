@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strconv"
 
 	"github.com/go-logr/logr"
 	"github.com/newrelic/newrelic-client-go/newrelic"
@@ -17,13 +16,8 @@ import (
 )
 
 const (
-	account           = "account"
-	queryKeyParamater = "queryKey"
-	regionParameter   = "region"
-	nrql              = "nrql"
-	threshold         = "threshold"
-	noDataError       = "noDataError"
-	scalerName        = "new-relic"
+	threshold  = "threshold"
+	scalerName = "new-relic"
 )
 
 type newrelicScaler struct {
@@ -34,14 +28,23 @@ type newrelicScaler struct {
 }
 
 type newrelicMetadata struct {
-	account             int
-	region              string
-	queryKey            string
-	noDataError         bool
-	nrql                string
-	threshold           float64
-	activationThreshold float64
-	triggerIndex        int
+	Account             int      `keda:"name=account,             order=authParams;triggerMetadata"`
+	Region              string   `keda:"name=region,              order=authParams;triggerMetadata, default=US, enum=US;EU;Staging, ignoreCase"`
+	QueryKey            string   `keda:"name=queryKey,            order=authParams;triggerMetadata, sensitive"`
+	NoDataError         bool     `keda:"name=noDataError,         order=triggerMetadata, default=false"`
+	NRQL                string   `keda:"name=nrql,                order=triggerMetadata"`
+	Threshold           *float64 `keda:"name=threshold,           order=triggerMetadata, optional"`
+	ActivationThreshold float64  `keda:"name=activationThreshold, order=triggerMetadata, default=0"`
+
+	triggerIndex   int
+	asMetricSource bool
+}
+
+func (m *newrelicMetadata) Validate() error {
+	if m.Threshold == nil && !m.asMetricSource {
+		return fmt.Errorf("missing %s value", threshold)
+	}
+	return nil
 }
 
 func NewNewRelicScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
@@ -52,20 +55,20 @@ func NewNewRelicScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 
 	logger := InitializeLogger(config, fmt.Sprintf("%s_scaler", scalerName))
 
-	meta, err := parseNewRelicMetadata(config, logger)
+	meta, err := parseNewRelicMetadata(config)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing %s metadata: %w", scalerName, err)
 	}
 
 	nrClient, err := newrelic.New(
-		newrelic.ConfigPersonalAPIKey(meta.queryKey),
-		newrelic.ConfigRegion(meta.region))
+		newrelic.ConfigPersonalAPIKey(meta.QueryKey),
+		newrelic.ConfigRegion(meta.Region))
 
 	if err != nil {
 		log.Fatal("error initializing client:", err)
 	}
 
-	logMsg := fmt.Sprintf("Initializing New Relic Scaler (account %d in region %s)", meta.account, meta.region)
+	logMsg := fmt.Sprintf("Initializing New Relic Scaler (account %d in region %s)", meta.Account, meta.Region)
 
 	logger.Info(logMsg)
 
@@ -76,76 +79,22 @@ func NewNewRelicScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		logger:     logger}, nil
 }
 
-func parseNewRelicMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*newrelicMetadata, error) {
-	meta := newrelicMetadata{}
-	var err error
-
-	val, err := GetFromAuthOrMeta(config, account)
-	if err != nil {
-		return nil, err
-	}
-
-	t, err := strconv.Atoi(val)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing %s: %w", account, err)
-	}
-	meta.account = t
-
-	if val, ok := config.TriggerMetadata[nrql]; ok && val != "" {
-		meta.nrql = val
-	} else {
-		return nil, fmt.Errorf("no %s given", nrql)
-	}
-
-	queryKey, err := GetFromAuthOrMeta(config, queryKeyParamater)
-	if err != nil {
+func parseNewRelicMetadata(config *scalersconfig.ScalerConfig) (*newrelicMetadata, error) {
+	meta := &newrelicMetadata{asMetricSource: config.AsMetricSource}
+	if err := config.TypedConfig(meta); err != nil {
 		return nil, err
 	}
-	meta.queryKey = queryKey
-
-	region, err := GetFromAuthOrMeta(config, regionParameter)
-	if err != nil {
-		region = "US"
-		logger.Info("Using default 'US' region")
-	}
-	meta.region = region
-
-	if val, ok := config.TriggerMetadata[threshold]; ok && val != "" {
-		t, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing %s", threshold)
-		}
-		meta.threshold = t
-	} else {
-		if config.AsMetricSource {
-			meta.threshold = 0
-		} else {
-			return nil, fmt.Errorf("missing %s value", threshold)
-		}
-	}
 
-	meta.activationThreshold = 0
-	if val, ok := config.TriggerMetadata["activationThreshold"]; ok {
-		activationThreshold, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("queryValue parsing error %w", err)
-		}
-		meta.activationThreshold = activationThreshold
-	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
 
-	// If Query Return an Empty Data , shall we treat it as an error or not
-	// default is NO error is returned when query result is empty/no data
-	if val, ok := config.TriggerMetadata[noDataError]; ok {
-		noDataError, err := strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("noDataError has invalid value")
-		}
-		meta.noDataError = noDataError
-	} else {
-		meta.noDataError = false
+// targetValue returns the threshold, it's only unset when the scaler is used as a metric source
+func (m *newrelicMetadata) targetValue() float64 {
+	if m.Threshold == nil {
+		return 0
 	}
-	meta.triggerIndex = config.TriggerIndex
-	return &meta, nil
+	return *m.Threshold
 }
 
 func (s *newrelicScaler) Close(context.Context) error {
@@ -153,15 +102,15 @@ func (s *newrelicScaler) Close(context.Context) error {
 }
 
 func (s *newrelicScaler) executeNewRelicQuery(ctx context.Context) (float64, error) {
-	nrdbQuery := nrdb.NRQL(s.metadata.nrql)
-	resp, err := s.nrClient.Nrdb.QueryWithContext(ctx, s.metadata.account, nrdbQuery)
+	nrdbQuery := nrdb.NRQL(s.metadata.NRQL)
+	resp, err := s.nrClient.Nrdb.QueryWithContext(ctx, s.metadata.Account, nrdbQuery)
 	if err != nil {
-		return 0, fmt.Errorf("error running NRQL %s (%s)", s.metadata.nrql, err.Error())
+		return 0, fmt.Errorf("error running NRQL %s (%s)", s.metadata.NRQL, err.Error())
 	}
 	// Check for empty results set, as New Relic lib does not report these as errors
 	if len(resp.Results) == 0 {
-		if s.metadata.noDataError {
-			return 0, fmt.Errorf("query return no results %s", s.metadata.nrql)
+		if s.metadata.NoDataError {
+			return 0, fmt.Errorf("query return no results %s", s.metadata.NRQL)
 		}
 		return 0, nil
 	}
@@ -172,8 +121,8 @@ func (s *newrelicScaler) executeNewRelicQuery(ctx context.Context) (float64, err
 			return val, nil
 		}
 	}
-	if s.metadata.noDataError {
-		return 0, fmt.Errorf("query return no results %s", s.metadata.nrql)
+	if s.metadata.NoDataError {
+		return 0, fmt.Errorf("query return no results %s", s.metadata.NRQL)
 	}
 	return 0, nil
 }
@@ -187,7 +136,7 @@ func (s *newrelicScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 
 	metric := GenerateMetricInMili(metricName, val)
 
-	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.activationThreshold, nil
+	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.ActivationThreshold, nil
 }
 
 func (s *newrelicScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
@@ -197,7 +146,7 @@ func (s *newrelicScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpe
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.threshold),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue()),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
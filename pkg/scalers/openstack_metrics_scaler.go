@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -21,29 +23,89 @@ import (
 )
 
 const (
-	defaultValueWhenError          = 0
-	metricDefaultHTTPClientTimeout = 30
+	defaultValueWhenError = 0
 )
 
 /* expected structure declarations */
 
 type openstackMetricMetadata struct {
-	metricsURL          string
-	metricID            string
-	aggregationMethod   string
-	granularity         int
-	threshold           float64
-	activationThreshold float64
-	timeout             int
-	triggerIndex        int
+	MetricsURL          string  `keda:"name=metricsURL,          order=triggerMetadata"`
+	MetricID            string  `keda:"name=metricID,            order=triggerMetadata"`
+	AggregationMethod   string  `keda:"name=aggregationMethod,   order=triggerMetadata"`
+	Granularity         int     `keda:"name=granularity,         order=triggerMetadata, min=1"`
+	Threshold           float64 `keda:"name=threshold,           order=triggerMetadata, default=0"`
+	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, default=0"`
+	// Timeout is the HTTP client timeout in seconds
+	Timeout int `keda:"name=timeout, order=triggerMetadata, default=30, min=0"`
+
+	triggerIndex int
 }
 
+// openstackMetricAggregationMethods are the aggregation methods of the Gnocchi archive policies, besides these
+// the percentiles, e.g. 90pct, and the rates of the methods, e.g. rate:mean, are supported too
+var openstackMetricAggregationMethods = []string{"mean", "median", "std", "min", "max", "sum", "var", "count", "first", "last"}
+
+func (m *openstackMetricMetadata) Validate() error {
+	if err := validateOpenstackURL(m.MetricsURL); err != nil {
+		return fmt.Errorf("invalid metricsURL: %w", err)
+	}
+	if !isOpenstackMetricAggregationMethod(strings.TrimPrefix(m.AggregationMethod, "rate:")) {
+		return fmt.Errorf("aggregationMethod must be one of %v, a percentile, e.g. 90pct, or a rate, e.g. rate:mean, got %q", openstackMetricAggregationMethods, m.AggregationMethod)
+	}
+	return nil
+}
+
+// isOpenstackMetricAggregationMethod checks the method is one of openstackMetricAggregationMethods or a percentile
+func isOpenstackMetricAggregationMethod(method string) bool {
+	if percentile, found := strings.CutSuffix(method, "pct"); found {
+		p, err := strconv.Atoi(percentile)
+		return err == nil && p > 0 && p < 100
+	}
+	return slices.Contains(openstackMetricAggregationMethods, method)
+}
+
+// openstackMetricAuthenticationMetadata supports either the password or the application credentials
+// authentication method, parameters of both methods can't be combined
 type openstackMetricAuthenticationMetadata struct {
-	userID                string
-	password              string
-	authURL               string
-	appCredentialSecret   string
-	appCredentialSecretID string
+	AuthURL string `keda:"name=authURL, order=authParams"`
+
+	// password authentication method
+	UserID   string `keda:"name=userID,   order=authParams, optional"`
+	Password string `keda:"name=password, order=authParams, optional"`
+
+	// application credentials authentication method
+	AppCredentialID     string `keda:"name=appCredentialID,     order=authParams, optional"`
+	AppCredentialSecret string `keda:"name=appCredentialSecret, order=authParams, optional"`
+}
+
+func (m *openstackMetricAuthenticationMetadata) Validate() error {
+	if err := validateOpenstackURL(m.AuthURL); err != nil {
+		return fmt.Errorf("invalid authURL: %w", err)
+	}
+
+	passwordAuth := m.UserID != "" || m.Password != ""
+	appCredentialAuth := m.AppCredentialID != "" || m.AppCredentialSecret != ""
+	switch {
+	case passwordAuth && appCredentialAuth:
+		return fmt.Errorf("userID and password can't be combined with appCredentialID and appCredentialSecret, use only one authentication method")
+	case passwordAuth:
+		if m.UserID == "" {
+			return fmt.Errorf("userID doesn't exist in the authParams")
+		}
+		if m.Password == "" {
+			return fmt.Errorf("password doesn't exist in the authParams")
+		}
+	case appCredentialAuth:
+		if m.AppCredentialID == "" {
+			return fmt.Errorf("appCredentialID doesn't exist in the authParams")
+		}
+		if m.AppCredentialSecret == "" {
+			return fmt.Errorf("appCredentialSecret doesn't exist in the authParams")
+		}
+	default:
+		return fmt.Errorf("neither userID or appCredentialID exist in the authParams")
+	}
+	return nil
 }
 
 type openstackMetricScaler struct {
@@ -84,22 +146,18 @@ func NewOpenstackMetricScaler(ctx context.Context, config *scalersconfig.ScalerC
 	}
 
 	// User choose the "application_credentials" authentication method
-	if authMetadata.appCredentialSecretID != "" {
-		keystoneAuth, err = openstack.NewAppCredentialsAuth(authMetadata.authURL, authMetadata.appCredentialSecretID, authMetadata.appCredentialSecret, openstackMetricMetadata.timeout)
+	if authMetadata.AppCredentialID != "" {
+		keystoneAuth, err = openstack.NewAppCredentialsAuth(authMetadata.AuthURL, authMetadata.AppCredentialID, authMetadata.AppCredentialSecret, openstackMetricMetadata.Timeout)
 
 		if err != nil {
 			return nil, fmt.Errorf("error getting openstack credentials for application credentials method: %w", err)
 		}
 	} else {
 		// User choose the "password" authentication method
-		if authMetadata.userID != "" {
-			keystoneAuth, err = openstack.NewPasswordAuth(authMetadata.authURL, authMetadata.userID, authMetadata.password, "", openstackMetricMetadata.timeout)
-
-			if err != nil {
-				return nil, fmt.Errorf("error getting openstack credentials for password method: %w", err)
-			}
-		} else {
-			return nil, fmt.Errorf("no authentication method was provided for OpenStack")
+		keystoneAuth, err = openstack.NewPasswordAuth(authMetadata.AuthURL, authMetadata.UserID, authMetadata.Password, "", openstackMetricMetadata.Timeout)
+
+		if err != nil {
+			return nil, fmt.Errorf("error getting openstack credentials for password method: %w", err)
 		}
 	}
 
@@ -119,70 +177,9 @@ func NewOpenstackMetricScaler(ctx context.Context, config *scalersconfig.ScalerC
 
 func parseOpenstackMetricMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*openstackMetricMetadata, error) {
 	meta := openstackMetricMetadata{}
-	triggerMetadata := config.TriggerMetadata
-
-	if val, ok := triggerMetadata["metricsURL"]; ok && val != "" {
-		meta.metricsURL = val
-	} else {
-		logger.Error(fmt.Errorf("no metrics url could be read"), "Error readig metricsURL")
-		return nil, fmt.Errorf("no metrics url was declared")
-	}
-
-	if val, ok := triggerMetadata["metricID"]; ok && val != "" {
-		meta.metricID = val
-	} else {
-		logger.Error(fmt.Errorf("no metric id could be read"), "Error reading metricID")
-		return nil, fmt.Errorf("no metric id was declared")
-	}
-
-	if val, ok := triggerMetadata["aggregationMethod"]; ok && val != "" {
-		meta.aggregationMethod = val
-	} else {
-		logger.Error(fmt.Errorf("no aggregation method could be read"), "Error reading aggregation method")
-		return nil, fmt.Errorf("no aggregation method could be read")
-	}
-
-	if val, ok := triggerMetadata["granularity"]; ok && val != "" {
-		granularity, err := strconv.Atoi(val)
-		if err != nil {
-			logger.Error(err, "Error converting granulality information %s", err.Error)
-			return nil, err
-		}
-		meta.granularity = granularity
-	} else {
-		return nil, fmt.Errorf("no granularity found")
-	}
-
-	if val, ok := triggerMetadata["threshold"]; ok && val != "" {
-		// converts the string to float64 but its value is convertible to float32 without changing
-		_threshold, err := strconv.ParseFloat(val, 32)
-		if err != nil {
-			logger.Error(err, "error parsing openstack metric metadata", "threshold", "threshold")
-			return nil, fmt.Errorf("error parsing openstack metric metadata : %w", err)
-		}
-
-		meta.threshold = _threshold
-	}
-
-	if val, ok := triggerMetadata["activationThreshold"]; ok && val != "" {
-		// converts the string to float64 but its value is convertible to float32 without changing
-		activationThreshold, err := strconv.ParseFloat(val, 32)
-		if err != nil {
-			logger.Error(err, "error parsing openstack metric metadata", "activationThreshold", "activationThreshold")
-			return nil, fmt.Errorf("error parsing openstack metric metadata : %w", err)
-		}
-
-		meta.activationThreshold = activationThreshold
-	}
-
-	if val, ok := triggerMetadata["timeout"]; ok && val != "" {
-		httpClientTimeout, err := strconv.Atoi(val)
-		if err != nil {
-			return nil, fmt.Errorf("httpClientTimeout parsing error: %w", err)
-		}
-		meta.timeout = httpClientTimeout
-	} else {
-		meta.timeout = metricDefaultHTTPClientTimeout
+	if err := config.TypedConfig(&meta); err != nil {
+		logger.V(1).Info("Error parsing openstack metric metadata", "error", err)
+		return nil, err
 	}
 	meta.triggerIndex = config.TriggerIndex
 	return &meta, nil
@@ -190,39 +187,18 @@ func parseOpenstackMetricMetadata(config *scalersconfig.ScalerConfig, logger log
 
 func parseOpenstackMetricAuthenticationMetadata(config *scalersconfig.ScalerConfig) (openstackMetricAuthenticationMetadata, error) {
 	authMeta := openstackMetricAuthenticationMetadata{}
-	authParams := config.AuthParams
-
-	if val, ok := authParams["authURL"]; ok && val != "" {
-		authMeta.authURL = authParams["authURL"]
-	} else {
-		return authMeta, fmt.Errorf("authURL doesn't exist in the authParams")
-	}
-
-	if val, ok := authParams["userID"]; ok && val != "" {
-		authMeta.userID = val
-
-		if val, ok := authParams["password"]; ok && val != "" {
-			authMeta.password = val
-		} else {
-			return authMeta, fmt.Errorf("password doesn't exist in the authParams")
-		}
-	} else if val, ok := authParams["appCredentialSecret"]; ok && val != "" {
-		authMeta.appCredentialSecretID = val
-	} else {
-		return authMeta, fmt.Errorf("neither userID or appCredentialSecretID exist in the authParams")
-	}
-
-	return authMeta, nil
+	err := config.TypedConfig(&authMeta)
+	return authMeta, err
 }
 
 func (s *openstackMetricScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	metricName := kedautil.NormalizeString(fmt.Sprintf("openstack-metric-%s", s.metadata.metricID))
+	metricName := kedautil.NormalizeString(fmt.Sprintf("openstack-metric-%s", s.metadata.MetricID))
 
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.threshold),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Threshold),
 	}
 
 	metricSpec := v2.MetricSpec{
@@ -243,7 +219,7 @@ func (s *openstackMetricScaler) GetMetricsAndActivity(ctx context.Context, metri
 
 	metric := GenerateMetricInMili(metricName, val)
 
-	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.activationThreshold, nil
+	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.ActivationThreshold, nil
 }
 
 func (s *openstackMetricScaler) Close(context.Context) error {
@@ -253,7 +229,7 @@ func (s *openstackMetricScaler) Close(context.Context) error {
 
 // Gets measureament from API as float64, converts it to int and return the value.
 func (s *openstackMetricScaler) readOpenstackMetrics(ctx context.Context) (float64, error) {
-	var metricURL = s.metadata.metricsURL
+	var metricURL = s.metadata.MetricsURL
 
 	isValid, validationError := s.metricClient.IsTokenValid(ctx)
 
@@ -279,21 +255,16 @@ func (s *openstackMetricScaler) readOpenstackMetrics(ctx context.Context) (float
 		return defaultValueWhenError, fmt.Errorf("metric url is invalid: %w", err)
 	}
 
-	openstackMetricsURL.Path = path.Join(openstackMetricsURL.Path, s.metadata.metricID+"/measures")
+	openstackMetricsURL.Path = path.Join(openstackMetricsURL.Path, s.metadata.MetricID+"/measures")
 	queryParameter := openstackMetricsURL.Query()
 	granularity := 0 // We start with granularity with value 2 cause gnocchi APIm which is used by openstack, consider a time window, and we want to get the last value
 
-	if s.metadata.granularity <= 0 {
-		s.logger.Error(fmt.Errorf("granularity value is less than 1"), "Minimum accepatble value expected for ganularity is 1.")
-		return defaultValueWhenError, fmt.Errorf("granularity value is less than 1")
-	}
-
-	if (s.metadata.granularity / 60) > 0 {
-		granularity = (s.metadata.granularity / 60) - 1
+	if (s.metadata.Granularity / 60) > 0 {
+		granularity = (s.metadata.Granularity / 60) - 1
 	}
 
-	queryParameter.Set("granularity", strconv.Itoa(s.metadata.granularity))
-	queryParameter.Set("aggregation", s.metadata.aggregationMethod)
+	queryParameter.Set("granularity", strconv.Itoa(s.metadata.Granularity))
+	queryParameter.Set("aggregation", s.metadata.AggregationMethod)
 
 	var currTimeWithWindow string
 
@@ -316,7 +287,7 @@ func (s *openstackMetricScaler) readOpenstackMetrics(ctx context.Context) (float
 	resp, requestError := s.metricClient.HTTPClient.Do(openstackMetricRequest)
 
 	if requestError != nil {
-		s.logger.Error(requestError, "Unable to request Metrics from URL: %s.", s.metadata.metricsURL)
+		s.logger.Error(requestError, "Unable to request Metrics from URL: %s.", s.metadata.MetricsURL)
 		return defaultValueWhenError, requestError
 	}
 
@@ -325,7 +296,7 @@ func (s *openstackMetricScaler) readOpenstackMetrics(ctx context.Context) (float
 		bodyError, readError := io.ReadAll(resp.Body)
 
 		if readError != nil {
-			s.logger.Error(readError, "Request failed with code: %s for URL: %s", resp.StatusCode, s.metadata.metricsURL)
+			s.logger.Error(readError, "Request failed with code: %s for URL: %s", resp.StatusCode, s.metadata.MetricsURL)
 			return defaultValueWhenError, readError
 		}
 
@@ -2,8 +2,10 @@ package scalers
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"net/url"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/gophercloud/gophercloud"
@@ -16,37 +18,96 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
-const (
-	defaultOnlyFiles             = false
-	defaultObjectCount           = 2
-	defaultActivationObjectCount = 0
-	defaultObjectLimit           = ""
-	defaultObjectPrefix          = ""
-	defaultObjectDelimiter       = ""
-	defaultHTTPClientTimeout     = 30
-)
-
 type openstackSwiftMetadata struct {
-	swiftURL              string
-	containerName         string
-	objectCount           int64
-	activationObjectCount int64
-	objectPrefix          string
-	objectDelimiter       string
-	objectLimit           string
-	httpClientTimeout     int
-	onlyFiles             bool
-	triggerIndex          int
+	// SwiftURL is optional, when empty the endpoint is discovered from the service catalog
+	SwiftURL              string `keda:"name=swiftURL,              order=triggerMetadata, optional"`
+	ContainerName         string `keda:"name=containerName,         order=triggerMetadata"`
+	ObjectCount           int64  `keda:"name=objectCount,           order=triggerMetadata, default=2"`
+	ActivationObjectCount int64  `keda:"name=activationObjectCount, order=triggerMetadata, default=0"`
+	ObjectPrefix          string `keda:"name=objectPrefix,          order=triggerMetadata, optional"`
+	ObjectDelimiter       string `keda:"name=objectDelimiter,       order=triggerMetadata, optional"`
+	ObjectLimit           string `keda:"name=objectLimit,           order=triggerMetadata, optional"`
+	// Timeout is the HTTP client timeout in seconds
+	Timeout   int  `keda:"name=timeout,   order=triggerMetadata, default=30, min=0"`
+	OnlyFiles bool `keda:"name=onlyFiles, order=triggerMetadata, default=false"`
+
+	httpClientTimeout time.Duration
+	triggerIndex      int
+}
+
+func (m *openstackSwiftMetadata) Validate() error {
+	if m.SwiftURL != "" {
+		if err := validateOpenstackURL(m.SwiftURL); err != nil {
+			return fmt.Errorf("invalid swiftURL: %w", err)
+		}
+	}
+	return nil
 }
 
+// openstackSwiftAuthenticationMetadata supports either the password or the application credentials
+// authentication method, parameters of both methods can't be combined
 type openstackSwiftAuthenticationMetadata struct {
-	userID              string
-	password            string
-	projectID           string
-	authURL             string
-	appCredentialID     string
-	appCredentialSecret string
-	regionName          string
+	AuthURL    string `keda:"name=authURL,    order=authParams"`
+	RegionName string `keda:"name=regionName, order=authParams, optional"`
+
+	// password authentication method
+	UserID    string `keda:"name=userID,    order=authParams, optional"`
+	Password  string `keda:"name=password,  order=authParams, optional, sensitive"`
+	ProjectID string `keda:"name=projectID, order=authParams, optional"`
+
+	// application credentials authentication method
+	AppCredentialID     string `keda:"name=appCredentialID,     order=authParams, optional, sensitive"`
+	AppCredentialSecret string `keda:"name=appCredentialSecret, order=authParams, optional, sensitive"`
+}
+
+func (m *openstackSwiftAuthenticationMetadata) Validate() error {
+	if err := validateOpenstackURL(m.AuthURL); err != nil {
+		return fmt.Errorf("invalid authURL: %w", err)
+	}
+
+	passwordAuth := m.UserID != "" || m.Password != "" || m.ProjectID != ""
+	appCredentialAuth := m.AppCredentialID != "" || m.AppCredentialSecret != ""
+	switch {
+	case passwordAuth && appCredentialAuth:
+		return fmt.Errorf("userID, password and projectID can't be combined with appCredentialID and appCredentialSecret, use only one authentication method")
+	case passwordAuth:
+		if m.UserID == "" {
+			return fmt.Errorf("userID doesn't exist in the authParams")
+		}
+		if m.Password == "" {
+			return fmt.Errorf("password doesn't exist in the authParams")
+		}
+		if m.ProjectID == "" {
+			return fmt.Errorf("projectID doesn't exist in the authParams")
+		}
+	case appCredentialAuth:
+		if m.AppCredentialID == "" {
+			return fmt.Errorf("appCredentialID doesn't exist in the authParams")
+		}
+		if m.AppCredentialSecret == "" {
+			return fmt.Errorf("appCredentialSecret doesn't exist in the authParams")
+		}
+	default:
+		return fmt.Errorf("neither userID or appCredentialID exist in the authParams")
+	}
+	return nil
+}
+
+// validateOpenstackURL checks the endpoint is an absolute URL, the error never contains the URL itself
+// as it may carry credentials
+func validateOpenstackURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("expected absolute URL with scheme and host")
+	}
+	return nil
 }
 
 type openstackSwiftScaler struct {
@@ -83,20 +144,20 @@ func NewOpenstackSwiftScaler(config *scalersconfig.ScalerConfig) (Scaler, error)
 	var authOpts *gophercloud.AuthOptions
 
 	// User chose the "application_credentials" authentication method
-	if authMetadata.appCredentialID != "" {
+	if authMetadata.AppCredentialID != "" {
 		authOpts = &gophercloud.AuthOptions{
-			IdentityEndpoint:            authMetadata.authURL,
-			ApplicationCredentialID:     authMetadata.appCredentialID,
-			ApplicationCredentialSecret: authMetadata.appCredentialSecret,
+			IdentityEndpoint:            authMetadata.AuthURL,
+			ApplicationCredentialID:     authMetadata.AppCredentialID,
+			ApplicationCredentialSecret: authMetadata.AppCredentialSecret,
 		}
-	} else if authMetadata.userID != "" {
+	} else {
 		// User chose the "password" authentication method
 		authOpts = &gophercloud.AuthOptions{
-			IdentityEndpoint: authMetadata.authURL,
-			UserID:           authMetadata.userID,
-			Password:         authMetadata.password,
+			IdentityEndpoint: authMetadata.AuthURL,
+			UserID:           authMetadata.UserID,
+			Password:         authMetadata.Password,
 			Scope: &gophercloud.AuthScope{
-				ProjectID: authMetadata.projectID,
+				ProjectID: authMetadata.ProjectID,
 			},
 		}
 	}
@@ -106,15 +167,15 @@ func NewOpenstackSwiftScaler(config *scalersconfig.ScalerConfig) (Scaler, error)
 		return nil, fmt.Errorf("error getting openstack client: %w", err)
 	}
 
-	swiftClient, err = openstack.NewObjectStorageV1(provider, gophercloud.EndpointOpts{Region: authMetadata.regionName})
+	swiftClient, err = openstack.NewObjectStorageV1(provider, gophercloud.EndpointOpts{Region: authMetadata.RegionName})
 	if err != nil {
 		return nil, fmt.Errorf("error getting openstack swift client: %w", err)
 	}
 
-	if openstackSwiftMetadata.swiftURL == "" {
-		openstackSwiftMetadata.swiftURL = swiftClient.Endpoint
+	if openstackSwiftMetadata.SwiftURL == "" {
+		openstackSwiftMetadata.SwiftURL = swiftClient.Endpoint
 	} else {
-		swiftClient.Endpoint = openstackSwiftMetadata.swiftURL
+		swiftClient.Endpoint = openstackSwiftMetadata.SwiftURL
 	}
 
 	return &openstackSwiftScaler{
@@ -127,123 +188,19 @@ func NewOpenstackSwiftScaler(config *scalersconfig.ScalerConfig) (Scaler, error)
 
 func parseOpenstackSwiftMetadata(config *scalersconfig.ScalerConfig) (*openstackSwiftMetadata, error) {
 	meta := openstackSwiftMetadata{}
-
-	if val, ok := config.TriggerMetadata["swiftURL"]; ok {
-		meta.swiftURL = val
-	} else {
-		meta.swiftURL = ""
-	}
-
-	if val, ok := config.TriggerMetadata["containerName"]; ok {
-		meta.containerName = val
-	} else {
-		return nil, fmt.Errorf("no containerName was provided")
-	}
-
-	if val, ok := config.TriggerMetadata["objectCount"]; ok {
-		objectCount, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("objectCount parsing error: %w", err)
-		}
-		meta.objectCount = objectCount
-	} else {
-		meta.objectCount = defaultObjectCount
-	}
-
-	if val, ok := config.TriggerMetadata["activationObjectCount"]; ok {
-		activationObjectCount, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("activationObjectCount parsing error: %w", err)
-		}
-		meta.activationObjectCount = activationObjectCount
-	} else {
-		meta.activationObjectCount = defaultActivationObjectCount
-	}
-
-	if val, ok := config.TriggerMetadata["objectPrefix"]; ok {
-		meta.objectPrefix = val
-	} else {
-		meta.objectPrefix = defaultObjectPrefix
-	}
-
-	if val, ok := config.TriggerMetadata["objectDelimiter"]; ok {
-		meta.objectDelimiter = val
-	} else {
-		meta.objectDelimiter = defaultObjectDelimiter
-	}
-
-	if val, ok := config.TriggerMetadata["timeout"]; ok {
-		httpClientTimeout, err := strconv.Atoi(val)
-		if err != nil {
-			return nil, fmt.Errorf("httpClientTimeout parsing error: %w", err)
-		}
-		meta.httpClientTimeout = httpClientTimeout
-	} else {
-		meta.httpClientTimeout = defaultHTTPClientTimeout
-	}
-
-	if val, ok := config.TriggerMetadata["onlyFiles"]; ok {
-		isOnlyFiles, conversionError := strconv.ParseBool(val)
-		if conversionError != nil {
-			return nil, fmt.Errorf("onlyFiles parsing error: %s", conversionError.Error())
-		}
-		meta.onlyFiles = isOnlyFiles
-	} else {
-		meta.onlyFiles = defaultOnlyFiles
-	}
-
-	if val, ok := config.TriggerMetadata["objectLimit"]; ok {
-		meta.objectLimit = val
-	} else {
-		meta.objectLimit = defaultObjectLimit
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, fmt.Errorf("error parsing swift metadata: %w", err)
 	}
+	meta.httpClientTimeout = time.Duration(meta.Timeout) * time.Second
 	meta.triggerIndex = config.TriggerIndex
 	return &meta, nil
 }
 
 func parseOpenstackSwiftAuthenticationMetadata(config *scalersconfig.ScalerConfig) (*openstackSwiftAuthenticationMetadata, error) {
 	authMeta := openstackSwiftAuthenticationMetadata{}
-
-	if config.AuthParams["authURL"] != "" {
-		authMeta.authURL = config.AuthParams["authURL"]
-	} else {
-		return nil, fmt.Errorf("authURL doesn't exist in the authParams")
-	}
-
-	if config.AuthParams["regionName"] != "" {
-		authMeta.regionName = config.AuthParams["regionName"]
-	} else {
-		authMeta.regionName = ""
-	}
-
-	if config.AuthParams["userID"] != "" {
-		authMeta.userID = config.AuthParams["userID"]
-
-		if config.AuthParams["password"] != "" {
-			authMeta.password = config.AuthParams["password"]
-		} else {
-			return nil, fmt.Errorf("password doesn't exist in the authParams")
-		}
-
-		if config.AuthParams["projectID"] != "" {
-			authMeta.projectID = config.AuthParams["projectID"]
-		} else {
-			return nil, fmt.Errorf("projectID doesn't exist in the authParams")
-		}
-	} else {
-		if config.AuthParams["appCredentialID"] != "" {
-			authMeta.appCredentialID = config.AuthParams["appCredentialID"]
-
-			if config.AuthParams["appCredentialSecret"] != "" {
-				authMeta.appCredentialSecret = config.AuthParams["appCredentialSecret"]
-			} else {
-				return nil, fmt.Errorf("appCredentialSecret doesn't exist in the authParams")
-			}
-		} else {
-			return nil, fmt.Errorf("neither userID or appCredentialID exist in the authParams")
-		}
+	if err := config.TypedConfig(&authMeta); err != nil {
+		return nil, fmt.Errorf("error parsing swift authentication metadata: %w", err)
 	}
-
 	return &authMeta, nil
 }
 
@@ -255,7 +212,7 @@ func (s *openstackSwiftScaler) Close(context.Context) error {
 }
 
 func (s *openstackSwiftScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	containerName := s.metadata.containerName
+	containerName := s.metadata.ContainerName
 	container, err := containers.Get(s.swiftClient, containerName, containers.GetOpts{}).Extract()
 	if err != nil {
 		s.logger.Error(err, "error getting container details")
@@ -264,16 +221,16 @@ func (s *openstackSwiftScaler) GetMetricsAndActivity(_ context.Context, metricNa
 	objectCount := container.ObjectCount
 	metric := GenerateMetricInMili(metricName, float64(objectCount))
 
-	return []external_metrics.ExternalMetricValue{metric}, objectCount > s.metadata.activationObjectCount, nil
+	return []external_metrics.ExternalMetricValue{metric}, objectCount > s.metadata.ActivationObjectCount, nil
 }
 
 func (s *openstackSwiftScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	var metricName string
 
-	if s.metadata.objectPrefix != "" {
-		metricName = fmt.Sprintf("%s-%s", s.metadata.containerName, s.metadata.objectPrefix)
+	if s.metadata.ObjectPrefix != "" {
+		metricName = fmt.Sprintf("%s-%s", s.metadata.ContainerName, s.metadata.ObjectPrefix)
 	} else {
-		metricName = s.metadata.containerName
+		metricName = s.metadata.ContainerName
 	}
 
 	metricName = kedautil.NormalizeString(fmt.Sprintf("openstack-swift-%s", metricName))
@@ -282,7 +239,7 @@ func (s *openstackSwiftScaler) GetMetricSpecForScaling(context.Context) []v2.Met
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.objectCount),
+		Target: GetMetricTarget(s.metricType, s.metadata.ObjectCount),
 	}
 
 	metricSpec := v2.MetricSpec{
@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
+	"net/url"
 	"strings"
 	"time"
 
@@ -29,11 +29,14 @@ type pulsarScaler struct {
 }
 
 type pulsarMetadata struct {
-	adminURL                      string
-	topic                         string
-	subscription                  string
-	msgBacklogThreshold           int64
-	activationMsgBacklogThreshold int64
+	AdminURL                      url.URL `keda:"name=adminURL,                      order=resolvedEnv;triggerMetadata"`
+	Topic                         string  `keda:"name=topic,                         order=resolvedEnv;triggerMetadata"`
+	Subscription                  string  `keda:"name=subscription,                  order=resolvedEnv;triggerMetadata"`
+	IsPartitionedTopic            bool    `keda:"name=isPartitionedTopic,            order=triggerMetadata, default=false"`
+	MsgBacklogThreshold           int64   `keda:"name=msgBacklogThreshold,           order=triggerMetadata, default=10"`
+	ActivationMsgBacklogThreshold int64   `keda:"name=activationMsgBacklogThreshold, order=triggerMetadata, default=0"`
+	// FIXME: msgBacklog support DEPRECATED to be removed in v2.14
+	MsgBacklog *int64 `keda:"name=msgBacklog, order=triggerMetadata, optional"`
 
 	pulsarAuth *authentication.AuthMeta
 
@@ -134,69 +137,24 @@ func NewPulsarScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 }
 
 func parsePulsarMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (pulsarMetadata, error) {
-	meta := pulsarMetadata{}
-	switch {
-	case config.TriggerMetadata["adminURLFromEnv"] != "":
-		meta.adminURL = config.ResolvedEnv[config.TriggerMetadata["adminURLFromEnv"]]
-	case config.TriggerMetadata["adminURL"] != "":
-		meta.adminURL = config.TriggerMetadata["adminURL"]
-	default:
-		return meta, errors.New("no adminURL given")
-	}
-
-	switch {
-	case config.TriggerMetadata["topicFromEnv"] != "":
-		meta.topic = config.ResolvedEnv[config.TriggerMetadata["topicFromEnv"]]
-	case config.TriggerMetadata["topic"] != "":
-		meta.topic = config.TriggerMetadata["topic"]
-	default:
-		return meta, errors.New("no topic given")
+	meta := pulsarMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(&meta); err != nil {
+		return meta, fmt.Errorf("error parsing pulsar metadata: %w", err)
 	}
 
-	topic := strings.ReplaceAll(meta.topic, "persistent://", "")
-	if config.TriggerMetadata["isPartitionedTopic"] == stringTrue {
-		meta.statsURL = meta.adminURL + "/admin/v2/persistent/" + topic + "/partitioned-stats"
+	topic := strings.ReplaceAll(meta.Topic, "persistent://", "")
+	if meta.IsPartitionedTopic {
+		meta.statsURL = meta.AdminURL.String() + "/admin/v2/persistent/" + topic + "/partitioned-stats"
 	} else {
-		meta.statsURL = meta.adminURL + "/admin/v2/persistent/" + topic + "/stats"
-	}
-
-	switch {
-	case config.TriggerMetadata["subscriptionFromEnv"] != "":
-		meta.subscription = config.ResolvedEnv[config.TriggerMetadata["subscriptionFromEnv"]]
-	case config.TriggerMetadata["subscription"] != "":
-		meta.subscription = config.TriggerMetadata["subscription"]
-	default:
-		return meta, errors.New("no subscription given")
-	}
-
-	meta.metricName = fmt.Sprintf("%s-%s-%s", "pulsar", meta.topic, meta.subscription)
-
-	meta.activationMsgBacklogThreshold = 0
-	if val, ok := config.TriggerMetadata["activationMsgBacklogThreshold"]; ok {
-		activationMsgBacklogThreshold, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("activationMsgBacklogThreshold parsing error %w", err)
-		}
-		meta.activationMsgBacklogThreshold = activationMsgBacklogThreshold
+		meta.statsURL = meta.AdminURL.String() + "/admin/v2/persistent/" + topic + "/stats"
 	}
 
-	meta.msgBacklogThreshold = defaultMsgBacklogThreshold
+	meta.metricName = fmt.Sprintf("%s-%s-%s", "pulsar", meta.Topic, meta.Subscription)
 
 	// FIXME: msgBacklog support DEPRECATED to be removed in v2.14
-	fmt.Println(config.TriggerMetadata)
-	if val, ok := config.TriggerMetadata[msgBacklogMetricName]; ok {
+	if meta.MsgBacklog != nil {
 		logger.V(1).Info("\"msgBacklog\" is deprecated and will be removed in v2.14, please use \"msgBacklogThreshold\" instead")
-		t, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing %s: %w", msgBacklogMetricName, err)
-		}
-		meta.msgBacklogThreshold = t
-	} else if val, ok := config.TriggerMetadata["msgBacklogThreshold"]; ok {
-		t, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing %s: %w", msgBacklogMetricName, err)
-		}
-		meta.msgBacklogThreshold = t
+		meta.MsgBacklogThreshold = *meta.MsgBacklog
 	}
 	// END FIXME
 
@@ -214,6 +172,18 @@ func parsePulsarMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 	if err != nil {
 		return meta, fmt.Errorf("error parsing %s: %w", msgBacklogMetricName, err)
 	}
+	if auth != nil {
+		// tls may be combined with any of these, but only one of them is sent with the request
+		modes := 0
+		for _, enabled := range []bool{auth.EnableBearerAuth, auth.EnableBasicAuth, auth.EnableOAuth} {
+			if enabled {
+				modes++
+			}
+		}
+		if modes > 1 {
+			return meta, errors.New("only one of bearer, basic or oauth authModes can be used at a time")
+		}
+	}
 
 	if auth != nil && auth.EnableOAuth {
 		if auth.OauthTokenURI == "" {
@@ -239,7 +209,6 @@ func parsePulsarMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		}
 	}
 	meta.pulsarAuth = auth
-	meta.triggerIndex = config.TriggerIndex
 	return meta, nil
 }
 
@@ -302,7 +271,7 @@ func (s *pulsarScaler) getMsgBackLog(ctx context.Context) (int64, bool, error) {
 		return 0, false, nil
 	}
 
-	v, found := stats.Subscriptions[s.metadata.subscription]
+	v, found := stats.Subscriptions[s.metadata.Subscription]
 
 	return v.Msgbacklog, found, nil
 }
@@ -315,16 +284,16 @@ func (s *pulsarScaler) GetMetricsAndActivity(ctx context.Context, metricName str
 	}
 
 	if !found {
-		return nil, false, fmt.Errorf("have not subscription found! %s", s.metadata.subscription)
+		return nil, false, fmt.Errorf("have not subscription found! %s", s.metadata.Subscription)
 	}
 
 	metric := GenerateMetricInMili(metricName, float64(msgBacklog))
 
-	return []external_metrics.ExternalMetricValue{metric}, msgBacklog > s.metadata.activationMsgBacklogThreshold, nil
+	return []external_metrics.ExternalMetricValue{metric}, msgBacklog > s.metadata.ActivationMsgBacklogThreshold, nil
 }
 
 func (s *pulsarScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	targetMetricValue := resource.NewQuantity(s.metadata.msgBacklogThreshold, resource.DecimalSI)
+	targetMetricValue := resource.NewQuantity(s.metadata.MsgBacklogThreshold, resource.DecimalSI)
 
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
@@ -21,6 +21,7 @@ type parsePulsarMetadataTestData struct {
 	adminURL           string
 	topic              string
 	subscription       string
+	parity             *metadataParity
 }
 
 type parsePulsarAuthParamsTestData struct {
@@ -60,26 +61,33 @@ var validPulsarWithoutAuthParams = map[string]string{}
 
 var parsePulsarMetadataTestDataset = []parsePulsarMetadataTestData{
 	// failure, no adminURL
-	{map[string]string{}, true, false, false, "", "", ""},
-	{map[string]string{"adminURL": "http://172.20.0.151:80"}, true, false, false, "http://172.20.0.151:80", "", ""},
-	{map[string]string{"adminURL": "http://172.20.0.151:80"}, true, false, false, "http://172.20.0.151:80", "", ""},
-	{map[string]string{"adminURL": "http://172.20.0.151:80"}, true, false, false, "http://172.20.0.151:80", "", ""},
-	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic"}, true, false, false, "http://172.20.0.151:80", "persistent://public/default/my-topic", ""},
-	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, false, true, false, "http://172.20.0.151:80", "persistent://public/default/my-topic", "sub1"},
-	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic", "subscription": "sub2"}, false, true, false, "http://172.20.0.151:80", "persistent://public/default/my-topic", "sub2"},
-	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic", "subscription": "sub3"}, false, false, false, "http://172.20.0.151:80", "persistent://public/default/my-topic", "sub3"},
-	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, false, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
-	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, false, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
-	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "true", "subscription": "sub1"}, false, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	{map[string]string{}, true, false, false, "", "", "", nil},
+	{map[string]string{"adminURL": "http://172.20.0.151:80"}, true, false, false, "http://172.20.0.151:80", "", "", nil},
+	{map[string]string{"adminURL": "http://172.20.0.151:80"}, true, false, false, "http://172.20.0.151:80", "", "", nil},
+	{map[string]string{"adminURL": "http://172.20.0.151:80"}, true, false, false, "http://172.20.0.151:80", "", "", nil},
+	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic"}, true, false, false, "http://172.20.0.151:80", "persistent://public/default/my-topic", "", nil},
+	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, false, true, false, "http://172.20.0.151:80", "persistent://public/default/my-topic", "sub1", nil},
+	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic", "subscription": "sub2"}, false, true, false, "http://172.20.0.151:80", "persistent://public/default/my-topic", "sub2", nil},
+	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic", "subscription": "sub3"}, false, false, false, "http://172.20.0.151:80", "persistent://public/default/my-topic", "sub3", nil},
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, false, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1", nil},
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, false, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1", nil},
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "true", "subscription": "sub1"}, false, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1", nil},
 	// test metric msgBacklogThreshold
-	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "true", "subscription": "sub1", "msgBacklogThreshold": "5"}, false, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "true", "subscription": "sub1", "msgBacklogThreshold": "5"}, false, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1", nil},
 	// FIXME: msgBacklog support DEPRECATED to be removed in v2.14
 	// test metric msgBacklog
-	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "true", "subscription": "sub1", "msgBacklog": "5"}, false, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "true", "subscription": "sub1", "msgBacklog": "5"}, false, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1", nil},
 	// END FIXME
 
+	// failure, adminURL is not an absolute URL
+	{map[string]string{"adminURL": "172.20.0.151", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, true, false, false, "", "persistent://public/default/my-topic", "sub1", nil},
+	// failure, invalid isPartitionedTopic
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "yes", "subscription": "sub1"}, true, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1", nil},
+
 	// tls
-	{map[string]string{"adminURL": "https://localhost:8443", "tls": "enable", "cert": "certdata", "key": "keydata", "ca": "cadata", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, false, true, false, "https://localhost:8443", "persistent://public/default/my-topic", "sub1"},
+	{map[string]string{"adminURL": "https://localhost:8443", "tls": "enable", "cert": "certdata", "key": "keydata", "ca": "cadata", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, false, true, false, "https://localhost:8443", "persistent://public/default/my-topic", "sub1", nil},
+	// msgBacklogThreshold default, partitioned stats URL and metric name
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "isPartitionedTopic": "true", "activationMsgBacklogThreshold": "3"}, false, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1", &metadataParity{fields: map[string]any{"statsURL": "http://127.0.0.1:8080/admin/v2/persistent/public/default/my-topic/partitioned-stats", "MsgBacklogThreshold": defaultMsgBacklogThreshold, "ActivationMsgBacklogThreshold": 3, "metricName": "pulsar-persistent://public/default/my-topic-sub1"}}},
 }
 
 var parsePulsarMetadataTestAuthTLSDataset = []parsePulsarAuthParamsTestData{
@@ -111,6 +119,8 @@ var parsePulsarMetadataTestAuthTLSDataset = []parsePulsarAuthParamsTestData{
 	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "authModes": "oauth"}, map[string]string{"ca": "cadata", "oauthTokenURI": "https5", "clientID": "id5", "clientSecret": "secret123"}, false, false, "", "", "cadata", "", "", "", false, "https5", "", "id5", "secret123", "", nil},
 	// Passes, invalid scopes provided
 	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "authModes": "oauth", "scope": "   "}, map[string]string{"ca": "cadata", "oauthTokenURI": "https5", "scope": " , \n", "clientID": "id5", "clientSecret": "secret123"}, false, false, "", "", "cadata", "", "", "", false, "https5", "", "id5", "secret123", "", nil},
+	// Fails, more than one of bearer, basic and oauth
+	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "authModes": "bearer,basic"}, map[string]string{"bearerToken": "my-special-token", "username": "admin", "password": "password123"}, true, false, "", "", "", "", "", "", false, "", "", "", "", "", nil},
 	// Passes, with audience provided in endpointParams
 	{map[string]string{"adminURL": "http://172.20.0.151:80", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "authModes": "oauth"}, map[string]string{"ca": "cadata", "oauthTokenURI": "https5", "clientID": "id5", "clientSecret": "secret123"}, false, false, "", "", "cadata", "", "", "", false, "https5", "", "id5", "secret123", "audience=abc", map[string][]string{"audience": {"abc"}}},
 }
@@ -131,8 +141,10 @@ func TestParsePulsarMetadata(t *testing.T) {
 			t.Error("Expected error but got success")
 		}
 
-		if meta.adminURL != testData.adminURL {
-			t.Errorf("Expected adminURL %s but got %s\n", testData.adminURL, meta.adminURL)
+		checkMetadataParity(t, meta, err, testData.parity)
+
+		if meta.AdminURL.String() != testData.adminURL {
+			t.Errorf("Expected adminURL %s but got %s\n", testData.adminURL, meta.AdminURL.String())
 		}
 
 		if !testData.isError {
@@ -147,12 +159,12 @@ func TestParsePulsarMetadata(t *testing.T) {
 			}
 		}
 
-		if meta.topic != testData.topic {
-			t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.topic)
+		if meta.Topic != testData.topic {
+			t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.Topic)
 		}
 
-		if meta.subscription != testData.subscription {
-			t.Errorf("Expected subscription %s but got %s\n", testData.subscription, meta.subscription)
+		if meta.Subscription != testData.subscription {
+			t.Errorf("Expected subscription %s but got %s\n", testData.subscription, meta.Subscription)
 		}
 
 		var testDataMsgBacklogThreshold int64
@@ -171,8 +183,8 @@ func TestParsePulsarMetadata(t *testing.T) {
 		} else {
 			testDataMsgBacklogThreshold = defaultMsgBacklogThreshold
 		}
-		if meta.msgBacklogThreshold != testDataMsgBacklogThreshold && testDataMsgBacklogThreshold != defaultMsgBacklogThreshold {
-			t.Errorf("Expected msgBacklogThreshold %s but got %d\n", testData.metadata["msgBacklogThreshold"], meta.msgBacklogThreshold)
+		if meta.MsgBacklogThreshold != testDataMsgBacklogThreshold && testDataMsgBacklogThreshold != defaultMsgBacklogThreshold {
+			t.Errorf("Expected msgBacklogThreshold %s but got %d\n", testData.metadata["msgBacklogThreshold"], meta.MsgBacklogThreshold)
 		}
 
 		authParams := validPulsarWithoutAuthParams
@@ -189,16 +201,16 @@ func TestParsePulsarMetadata(t *testing.T) {
 			t.Error("Expected error but got success")
 		}
 
-		if meta.adminURL != testData.adminURL {
-			t.Errorf("Expected adminURL %s but got %s\n", testData.adminURL, meta.adminURL)
+		if meta.AdminURL.String() != testData.adminURL {
+			t.Errorf("Expected adminURL %s but got %s\n", testData.adminURL, meta.AdminURL.String())
 		}
 
-		if meta.topic != testData.topic {
-			t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.topic)
+		if meta.Topic != testData.topic {
+			t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.Topic)
 		}
 
-		if meta.subscription != testData.subscription {
-			t.Errorf("Expected subscription %s but got %s\n", testData.subscription, meta.subscription)
+		if meta.Subscription != testData.subscription {
+			t.Errorf("Expected subscription %s but got %s\n", testData.subscription, meta.Subscription)
 		}
 	}
 }
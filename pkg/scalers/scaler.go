@@ -168,6 +168,39 @@ func GenerateMetricInMili(metricName string, value float64) external_metrics.Ext
 	}
 }
 
+// redactedError is the error with the secrets replaced in the message of the wrapped error
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactSecrets returns the error with the secrets replaced in its message, the original error is kept
+// in the chain for errors.Is and errors.As and it's returned as is if the message contains no secret
+func redactSecrets(err error, secrets ...string) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	redacted := msg
+	for _, secret := range secrets {
+		if secret != "" {
+			redacted = strings.ReplaceAll(redacted, secret, "xxx")
+		}
+	}
+	if redacted == msg {
+		return err
+	}
+	return &redactedError{msg: redacted, err: err}
+}
+
 // Option represents a function type that modifies a configOptions instance.
 type Option func(*configOptions)
 
@@ -1,8 +1,13 @@
 package scalers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -252,6 +257,18 @@ var getParameterFromConfigTestDataset = []getParameterFromConfigTestData{
 	},
 }
 
+func TestRedactSecrets(t *testing.T) {
+	original := fmt.Errorf("dial etcd-user:Secret123@172.0.0.1:2379 failed: %w", context.DeadlineExceeded)
+	err := redactSecrets(original, "Secret123", "")
+	assert.EqualError(t, err, "dial etcd-user:xxx@172.0.0.1:2379 failed: context deadline exceeded")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, original)
+
+	original = errors.New("context deadline exceeded")
+	assert.Same(t, original, redactSecrets(original, "Secret123"))
+	assert.NoError(t, redactSecrets(nil, "Secret123"))
+}
+
 func TestGetParameterFromConfigV2(t *testing.T) {
 	for _, testData := range getParameterFromConfigTestDataset {
 		val, err := getParameterFromConfigV2(
@@ -473,3 +490,63 @@ func TestConvertStringToType(t *testing.T) {
 		}
 	}
 }
+
+// metadataParity is the result of the previous hand-written metadata parser the typed config has to keep,
+// the fields are looked up by their dotted path in the parsed metadata, e.g. "Region" or "info.ClientID"
+type metadataParity struct {
+	fields  map[string]any
+	err     string
+	secrets []string
+}
+
+// checkMetadataParity is a function that compares the parsed metadata or the parsing error with the parity,
+// the secrets must not be echoed in the error either way
+func checkMetadataParity(t *testing.T, meta any, err error, parity *metadataParity) {
+	t.Helper()
+	if parity == nil {
+		return
+	}
+	if err != nil {
+		for _, secret := range parity.secrets {
+			assert.NotContainsf(t, err.Error(), secret, "error leaks secret %q", secret)
+		}
+	}
+	if parity.err != "" {
+		assert.ErrorContains(t, err, parity.err)
+		return
+	}
+	if len(parity.fields) == 0 || !assert.NoError(t, err) {
+		return
+	}
+	for path, expected := range parity.fields {
+		val, ok := metadataFieldValue(reflect.ValueOf(meta), path)
+		if assert.Truef(t, ok, "unknown metadata field %s", path) {
+			assert.Equalf(t, fmt.Sprint(expected), val, "metadata field %s", path)
+		}
+	}
+}
+
+// metadataFieldValue is a function that prints the value of the metadata field found by its dotted path,
+// pointers are dereferenced and the fmt.Stringer values, e.g. url.URL, are printed with their String method
+func metadataFieldValue(v reflect.Value, path string) (string, bool) {
+	for _, name := range strings.Split(path, ".") {
+		v = reflect.Indirect(v)
+		if v.Kind() != reflect.Struct {
+			return "", false
+		}
+		if v = v.FieldByName(name); !v.IsValid() {
+			return "", false
+		}
+	}
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.CanAddr() {
+		// unexported fields can't be interfaced, read them through their address
+		ptr := reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr()))
+		if stringer, ok := ptr.Interface().(fmt.Stringer); ok {
+			return stringer.String(), true
+		}
+	}
+	return fmt.Sprint(v), true
+}
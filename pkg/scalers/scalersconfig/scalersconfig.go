@@ -17,8 +17,12 @@ limitations under the License.
 package scalersconfig
 
 import (
+	"context"
+	"maps"
+	"slices"
 	"time"
 
+	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -54,6 +58,10 @@ type ScalerConfig struct {
 	// AuthParams
 	AuthParams map[string]string
 
+	// CustomSources are named extra parameter sources, e.g. annotations, referenced
+	// from the typed config parsing order as custom:<name>
+	CustomSources map[string]map[string]string
+
 	// PodIdentity
 	PodIdentity kedav1alpha1.AuthPodIdentity
 
@@ -68,4 +76,92 @@ type ScalerConfig struct {
 
 	// When we use the scaler for composite scaler, we shouldn't require the value because it'll be ignored
 	AsMetricSource bool
+
+	// AllowEmptyValues makes the typed config treat a present key with an empty value as a provided value
+	// for all parameters, the same as the per-field 'allowEmpty' tag
+	AllowEmptyValues bool
+
+	// AllowUnresolvedDefaults makes the typed config keep the ${namespace}, ${name} and ${triggerName} placeholders
+	// in the default values as they are when the value isn't set, instead of returning an error
+	AllowUnresolvedDefaults bool
+
+	// MaxElems limits the number of elements parsed into slice and map parameters without the 'maxElems' tag,
+	// the typed config uses 10000 if not set
+	MaxElems int
+
+	// ValueInterceptor is called once with the name and the value of every parameter found in the sources before
+	// the value is parsed, e.g. to decrypt the values, the default values aren't passed through it
+	ValueInterceptor func(name, value string) (string, error)
+
+	// ValueInterceptorContext works like ValueInterceptor but receives the context passed to TypedConfigContext,
+	// e.g. to cancel a remote decryption, it takes precedence over ValueInterceptor
+	ValueInterceptorContext func(ctx context.Context, name, value string) (string, error)
+
+	// MissingErrorTemplate is the text/template of the missing required parameter error with the MissingParam data,
+	// e.g. "{{.Name}} is required", the default is "missing required {{.Field}} in {{.Order}}"
+	MissingErrorTemplate string
+
+	// ElemSeparator is the default separator of the slice, set and map elements for all parameters, e.g. ';'
+	// for comma-heavy values, the ElemSeparatorProvider of the typed config takes precedence, ',' is used if not set
+	ElemSeparator string
+
+	// ParsingOrderOverride is the parsing order the typed config uses for the parameters without the 'order' tag,
+	// parameters with the 'order' tag keep their own order unless ForceParsingOrderOverride is set
+	ParsingOrderOverride []ParsingOrder
+
+	// ReverseParsingOrder makes the typed config try the sources in the reversed order, e.g. resolvedEnv before
+	// triggerMetadata, it's applied after ParsingOrderOverride and to the same set of parameters
+	ReverseParsingOrder bool
+
+	// ForceParsingOrderOverride makes ParsingOrderOverride and ReverseParsingOrder apply also to the parameters
+	// with the 'order' tag
+	ForceParsingOrderOverride bool
+
+	// NameVariables are the values of the ${variable} placeholders in the parameter names that don't reference
+	// another parameter, e.g. name=queue_${env}_length with NameVariables {"env": "prod"} looks up queue_prod_length
+	NameVariables map[string]string
+
+	// AllowedHosts is the central allowlist of the URL hosts applied to all the parameters with the 'allowHosts' tag
+	// on top of the hosts from the tag, the *. prefix matches any subdomain, e.g. *.svc.cluster.local
+	AllowedHosts []string
+
+	// Gates are the enabled feature gates, parameters with the 'gated' tag are only parsed when their gate is enabled
+	Gates map[string]bool
+
+	// Logger receives the warnings of the typed config, e.g. about the ignored gated parameters,
+	// the zero value discards them
+	Logger logr.Logger
+
+	// report collects the consumed keys and the parameters not found, it's only set on the private copy
+	// of the ScalerConfig used by TypedConfigWithReport
+	report *configReport
+
+	// keyPrefix is the joined 'prefix' of the nested structs, it's only set on the private copies of the ScalerConfig
+	// used for parsing the nested structs with the 'prefix' tag
+	keyPrefix string
+
+	// defaultsOnly makes the typed config set only the fields with the 'default' tag, it's only set on the private
+	// copy of the ScalerConfig used by ApplyDefaults
+	defaultsOnly bool
+}
+
+// Clone returns a deep copy of the ScalerConfig, the maps, the parsing order override and the pod identity
+// are copied so the clone can be parsed or modified concurrently with the original
+func (sc *ScalerConfig) Clone() *ScalerConfig {
+	clone := *sc
+	clone.TriggerMetadata = maps.Clone(sc.TriggerMetadata)
+	clone.ResolvedEnv = maps.Clone(sc.ResolvedEnv)
+	clone.AuthParams = maps.Clone(sc.AuthParams)
+	if sc.CustomSources != nil {
+		clone.CustomSources = make(map[string]map[string]string, len(sc.CustomSources))
+		for name, source := range sc.CustomSources {
+			clone.CustomSources[name] = maps.Clone(source)
+		}
+	}
+	clone.PodIdentity = *sc.PodIdentity.DeepCopy()
+	clone.ParsingOrderOverride = slices.Clone(sc.ParsingOrderOverride)
+	clone.Gates = maps.Clone(sc.Gates)
+	clone.AllowedHosts = slices.Clone(sc.AllowedHosts)
+	clone.NameVariables = maps.Clone(sc.NameVariables)
+	return &clone
 }
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalersconfig
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// TestClone tests the clone doesn't share any state with the original
+func TestClone(t *testing.T) {
+	Expect := NewWithT(t).Expect
+	identityID := "id"
+	sc := &ScalerConfig{
+		TriggerName:          "trigger",
+		TriggerMetadata:      map[string]string{"a": "1"},
+		ResolvedEnv:          map[string]string{"b": "2"},
+		AuthParams:           map[string]string{"c": "3"},
+		CustomSources:        map[string]map[string]string{"annotations": {"d": "4"}},
+		PodIdentity:          kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload, IdentityID: &identityID},
+		ParsingOrderOverride: []ParsingOrder{AuthParams},
+		Gates:                map[string]bool{"experimental": true},
+		NameVariables:        map[string]string{"env": "prod"},
+		AllowedHosts:         []string{"*.example.com"},
+	}
+
+	clone := sc.Clone()
+	Expect(clone).To(Equal(sc))
+
+	clone.TriggerMetadata["a"] = "changed"
+	clone.ResolvedEnv["b"] = "changed"
+	clone.AuthParams["c"] = "changed"
+	clone.CustomSources["annotations"]["d"] = "changed"
+	*clone.PodIdentity.IdentityID = "changed"
+	clone.ParsingOrderOverride[0] = TriggerMetadata
+	clone.Gates["experimental"] = false
+	clone.NameVariables["env"] = "changed"
+	clone.AllowedHosts[0] = "changed"
+
+	Expect(sc.TriggerMetadata).To(Equal(map[string]string{"a": "1"}))
+	Expect(sc.ResolvedEnv).To(Equal(map[string]string{"b": "2"}))
+	Expect(sc.AuthParams).To(Equal(map[string]string{"c": "3"}))
+	Expect(sc.CustomSources).To(Equal(map[string]map[string]string{"annotations": {"d": "4"}}))
+	Expect(identityID).To(Equal("id"))
+	Expect(sc.ParsingOrderOverride).To(Equal([]ParsingOrder{AuthParams}))
+	Expect(sc.Gates).To(Equal(map[string]bool{"experimental": true}))
+	Expect(sc.NameVariables).To(Equal(map[string]string{"env": "prod"}))
+	Expect(sc.AllowedHosts).To(Equal([]string{"*.example.com"}))
+
+	Expect((&ScalerConfig{}).Clone()).To(Equal(&ScalerConfig{}))
+}
+
+// TestCloneConcurrentParsing tests the clones can be parsed and modified concurrently, run with -race
+func TestCloneConcurrentParsing(t *testing.T) {
+	Expect := NewWithT(t).Expect
+	sc := &ScalerConfig{
+		TriggerMetadata: map[string]string{"intVal": "1", "stringVal": "value"},
+	}
+
+	type testStruct struct {
+		IntVal    int    `keda:"name=intVal,    order=triggerMetadata"`
+		StringVal string `keda:"name=stringVal, order=triggerMetadata"`
+	}
+
+	const workers = 10
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		clone := sc.Clone()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clone.TriggerMetadata["intVal"] = fmt.Sprint(i)
+			ts := testStruct{}
+			if errs[i] = clone.TypedConfig(&ts); errs[i] == nil && ts.IntVal != i {
+				errs[i] = fmt.Errorf("worker %d parsed %d", i, ts.IntVal)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		Expect(err).To(BeNil())
+	}
+	Expect(sc.TriggerMetadata["intVal"]).To(Equal("1"))
+}
@@ -0,0 +1,3690 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scalersconfig contains the configuration shared by all scalers and the typed config
+// which parses the trigger metadata, resolved environment and auth params into a tagged struct, e.g.
+//
+//	type kubernetesWorkloadMetadata struct {
+//		PodSelector     labels.Selector `keda:"name=podSelector,     order=triggerMetadata"`
+//		Value           float64         `keda:"name=value,           order=triggerMetadata, optional"`
+//		ActivationValue float64         `keda:"name=activationValue, order=triggerMetadata, default=0"`
+//	}
+//
+//	meta := &kubernetesWorkloadMetadata{}
+//	err := config.TypedConfig(meta)
+//
+// Cross-field checks go to the Validate method of the CustomValidator interface, it's called once
+// all the fields of the struct are parsed.
+package scalersconfig
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// CustomValidator is an interface that can be implemented to validate the configuration of the typed config
+type CustomValidator interface {
+	Validate() error
+}
+
+// CustomValidatorContext is an interface that works like CustomValidator but receives the context passed
+// to TypedConfigContext, e.g. to look up the referenced resources, it takes precedence over CustomValidator
+type CustomValidatorContext interface {
+	ValidateContext(ctx context.Context) error
+}
+
+// ElemSeparatorProvider is an interface that can be implemented by the typed config or its nested structs to switch
+// the default separator of the slice and map elements of the struct fields, e.g. to ';' for comma-heavy values,
+// it takes precedence over ScalerConfig.ElemSeparator and applies to the nested structs as well
+type ElemSeparatorProvider interface {
+	ElemSeparator() string
+}
+
+// ValidatorFunc is a function validating the parameter referencing it with the 'validate' tag, it receives
+// the value from the config and the field the value was already assigned to
+type ValidatorFunc func(value string, field reflect.Value) error
+
+// validators are the ValidatorFunc registered with RegisterValidator
+var (
+	validatorsMu sync.RWMutex
+	validators   = map[string]ValidatorFunc{}
+)
+
+// RegisterValidator registers the validator under the name referenced by the 'validate' tag, e.g. validate=name,
+// it's meant to be called from init functions and panics if the validator is nil or the name is already registered
+func RegisterValidator(name string, validator ValidatorFunc) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	if validator == nil {
+		panic(fmt.Sprintf("validator %q is nil", name))
+	}
+	if _, exists := validators[name]; exists {
+		panic(fmt.Sprintf("validator %q is already registered", name))
+	}
+	validators[name] = validator
+}
+
+// lookupValidator is a function that returns the validator registered under the name
+func lookupValidator(name string) (ValidatorFunc, bool) {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+	validator, ok := validators[name]
+	return validator, ok
+}
+
+// CodecFunc is a function parsing the value from the config into a value assignable to the field type
+// it's registered for with RegisterCodec
+type CodecFunc func(value string) (any, error)
+
+// CodecContextFunc is a function that works like CodecFunc but receives the context passed to TypedConfigContext,
+// it's registered with RegisterCodecContext
+type CodecContextFunc func(ctx context.Context, value string) (any, error)
+
+// codecs are the codecs registered with RegisterCodec and RegisterCodecContext
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[reflect.Type]CodecContextFunc{}
+)
+
+// RegisterCodec registers the codec parsing the fields of the type, it takes precedence over the built-in parsing
+// and the TextUnmarshaler implementation, it's meant to be called from init functions and panics if the codec
+// is nil or the type is already registered
+func RegisterCodec(typ reflect.Type, codec CodecFunc) {
+	if codec == nil {
+		panic(fmt.Sprintf("codec for type %v is nil", typ))
+	}
+	RegisterCodecContext(typ, func(_ context.Context, value string) (any, error) {
+		return codec(value)
+	})
+}
+
+// RegisterCodecContext registers the context-aware codec parsing the fields of the type, e.g. to resolve
+// the values remotely, it panics like RegisterCodec
+func RegisterCodecContext(typ reflect.Type, codec CodecContextFunc) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	if typ == nil || codec == nil {
+		panic(fmt.Sprintf("codec for type %v is nil", typ))
+	}
+	if _, exists := codecs[typ]; exists {
+		panic(fmt.Sprintf("codec for type %v is already registered", typ))
+	}
+	codecs[typ] = codec
+}
+
+// lookupCodec is a function that returns the codec registered for the type
+func lookupCodec(typ reflect.Type) (CodecContextFunc, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[typ]
+	return codec, ok
+}
+
+// RegisterEnum registers the codec parsing the fields of the enum type T from the names of its values,
+// e.g. RegisterEnum(map[string]Mode{"fast": ModeFast, "safe": ModeSafe}), the names are matched case sensitively
+// first and case insensitively if there is no exact match, it panics like RegisterCodec and for an empty table
+func RegisterEnum[T comparable](names map[string]T) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if len(names) == 0 {
+		panic(fmt.Sprintf("enum %v has no names", typ))
+	}
+	names = maps.Clone(names)
+	RegisterCodec(typ, func(value string) (any, error) {
+		if val, ok := names[value]; ok {
+			return val, nil
+		}
+		for name, val := range names {
+			if strings.EqualFold(name, value) {
+				return val, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown %v value %q, has to be one of %v", typ, value, sortedKeys(names))
+	})
+}
+
+// RegisterEnumValues registers the enum type T with the names returned by the String method of the values,
+// e.g. RegisterEnumValues(ModeFast, ModeSafe), it panics like RegisterEnum and for duplicate names
+func RegisterEnumValues[T interface {
+	comparable
+	fmt.Stringer
+}](values ...T) {
+	names := make(map[string]T, len(values))
+	for _, val := range values {
+		if _, exists := names[val.String()]; exists {
+			panic(fmt.Sprintf("enum %T has duplicate name %q", val, val.String()))
+		}
+		names[val.String()] = val
+	}
+	RegisterEnum(names)
+}
+
+// ParsingOrder is a type that represents the order in which the parameters are parsed
+type ParsingOrder string
+
+// Constants that represent the order in which the parameters are parsed, resolvedEnv is looked up through
+// the <name>FromEnv triggerMetadata key and authParams of the fields with the 'fromAuth' tag may be redirected
+// with the <name>FromAuth key
+const (
+	TriggerMetadata ParsingOrder = "triggerMetadata"
+	ResolvedEnv     ParsingOrder = "resolvedEnv"
+	AuthParams      ParsingOrder = "authParams"
+)
+
+// defaultParsingOrder is the parsing order of the parameters without the 'order' tag
+// when ScalerConfig.ParsingOrderOverride isn't set
+var defaultParsingOrder = []ParsingOrder{TriggerMetadata, ResolvedEnv, AuthParams}
+
+// customParsingOrderPrefix is the prefix of parsing orders referencing ScalerConfig.CustomSources
+// e.g. order=triggerMetadata;custom:annotations
+const customParsingOrderPrefix = "custom:"
+
+// CustomSource returns the ParsingOrder referencing the named source in ScalerConfig.CustomSources
+func CustomSource(name string) ParsingOrder {
+	return ParsingOrder(customParsingOrderPrefix + name)
+}
+
+// customSourceName returns the name of the custom source and true if the parsing order references one
+func (po ParsingOrder) customSourceName() (string, bool) {
+	name, found := strings.CutPrefix(string(po), customParsingOrderPrefix)
+	return name, found
+}
+
+// allowedParsingOrderMap is a map with set of valid parsing orders
+var allowedParsingOrderMap = map[ParsingOrder]bool{
+	TriggerMetadata: true,
+	ResolvedEnv:     true,
+	AuthParams:      true,
+}
+
+// separators for field tag structure
+// e.g. name=stringVal,order=triggerMetadata;resolvedEnv;authParams,optional
+const (
+	tagSeparator      = ","
+	tagKeySeparator   = "="
+	tagValueSeparator = ";"
+	tagValueQuote     = "'"
+)
+
+// separators for map and slice elements, the elements of the slice values of maps are separated
+// with the nested separator, e.g. a=1;2,b=3
+const (
+	elemSeparator       = ","
+	elemKeyValSeparator = "="
+	nestedElemSeparator = ";"
+)
+
+// defaultMaxElems is the maximum number of elements parsed into a slice or map parameter
+// when neither the 'maxElems' tag nor ScalerConfig.MaxElems is set
+const defaultMaxElems = 10000
+
+// default separators for the rows and columns of two dimensional slices, e.g. a,b;c,d
+const (
+	defaultRowSeparator    = ";"
+	defaultColumnSeparator = ","
+)
+
+// skipTag is the whole tag of the fields the typed config skips, e.g. `keda:"-"` the same as with encoding/json
+const skipTag = "-"
+
+// field tag parameters
+const (
+	optionalTag        = "optional"
+	deprecatedTag      = "deprecated"
+	defaultTag         = "default"
+	orderTag           = "order"
+	nameTag            = "name"
+	negateTag          = "negate"
+	onDuplicateTag     = "onDuplicate"
+	docTag             = "doc"
+	allowEmptyTag      = "allowEmpty"
+	defaultOnEmptyTag  = "defaultOnEmpty"
+	requiredKeysTag    = "requiredKeys"
+	rowSeparatorTag    = "rowSeparator"
+	columnSeparatorTag = "columnSeparator"
+	rectangularTag     = "rectangular"
+	templateTag        = "template"
+	maxElemsTag        = "maxElems"
+	rateTag            = "rate"
+	lazyTag            = "lazy"
+	quantityTag        = "quantity"
+	removedInTag       = "removedIn"
+	keyPrefixTag       = "keyPrefix"
+	multipleOfTag      = "multipleOf"
+	trueTokenTag       = "trueToken"
+	falseTokenTag      = "falseToken"
+	typeHintsTag       = "typeHints"
+	validateTag        = "validate"
+	durationUnitTag    = "durationUnit"
+	durationModeTag    = "durationMode"
+	wholeMetadataTag   = "wholeMetadata"
+	humanIntTag        = "humanInt"
+	fallbackTag        = "fallback"
+	ratioTag           = "ratio"
+	presenceTrueTag    = "presenceTrue"
+	defaultUnitTag     = "defaultUnit"
+	percentTag         = "percent"
+	csvTag             = "csv"
+	trimCutsetTag      = "trimCutset"
+	gatedTag           = "gated"
+	stripPrefixTag     = "stripPrefix"
+	renamedFromTag     = "renamedFrom"
+	decimalCommaTag    = "decimalComma"
+	allowHostsTag      = "allowHosts"
+	clampTag           = "clamp"
+	uniqueTag          = "unique"
+	jsonPathTag        = "jsonPath"
+	schemesTag         = "schemes"
+	enumTag            = "enum"
+	ignoreCaseTag      = "ignoreCase"
+	minTag             = "min"
+	maxTag             = "max"
+	prefixTag          = "prefix"
+	separatorTag       = "separator"
+	keyValSeparatorTag = "keyValSeparator"
+	fromAuthTag        = "fromAuth"
+	sensitiveTag       = "sensitive"
+	exclusiveSetTag    = "exclusiveSet"
+	requiredIfTag      = "requiredIf"
+)
+
+// sensitiveValuePlaceholder replaces the values of the 'sensitive' parameters in the parsing errors
+const sensitiveValuePlaceholder = "[redacted]"
+
+// field tag parameters comparing the parsed numeric value with a sibling field of the same type
+const (
+	lessThanTag           = "lessThan"
+	lessThanOrEqualTag    = "lessThanOrEqual"
+	greaterThanTag        = "greaterThan"
+	greaterThanOrEqualTag = "greaterThanOrEqual"
+)
+
+// fieldComparisons is a map of the comparison tags to the description and the check of the comparison result
+var fieldComparisons = map[string]struct {
+	desc  string
+	check func(result int) bool
+}{
+	lessThanTag:           {"less than", func(result int) bool { return result < 0 }},
+	lessThanOrEqualTag:    {"less than or equal to", func(result int) bool { return result <= 0 }},
+	greaterThanTag:        {"greater than", func(result int) bool { return result > 0 }},
+	greaterThanOrEqualTag: {"greater than or equal to", func(result int) bool { return result >= 0 }},
+}
+
+// FieldComparison is a comparison of the parsed numeric value with the sibling Field, Tag is one of the
+// 'lessThan', 'lessThanOrEqual', 'greaterThan' or 'greaterThanOrEqual' tags
+type FieldComparison struct {
+	Tag   string
+	Field string
+}
+
+// requiredIfValueSeparator separates the sibling field name and the value in the 'requiredIf' tag, e.g. Scope:repo
+const requiredIfValueSeparator = ":"
+
+// FieldCondition is a condition of the 'requiredIf' tag on the sibling Field, it holds if the Field is set
+// to a non-zero value or, with the Value, if the Field formatted with fmt.Sprint equals to the Value
+type FieldCondition struct {
+	Field string
+	Value string
+}
+
+// String is a function that returns the description of the condition as used in the error messages
+func (c FieldCondition) String() string {
+	if c.Value == "" {
+		return "is set"
+	}
+	return fmt.Sprintf("is %q", c.Value)
+}
+
+// PercentMode is a type that represents how the percentages are stored in the float fields
+type PercentMode string
+
+// Constants that represent how the percentages are stored in the float fields
+const (
+	// PercentModeFraction stores the percentage as a fraction, e.g. -25% is -0.25, this is the default
+	PercentModeFraction PercentMode = "fraction"
+	// PercentModeWhole stores the percentage as is, e.g. -25% is -25
+	PercentModeWhole PercentMode = "whole"
+)
+
+// allowedPercentModeMap is a map with set of valid percentage modes
+var allowedPercentModeMap = map[PercentMode]bool{
+	PercentModeFraction: true,
+	PercentModeWhole:    true,
+}
+
+// FallbackStep is a single step of the 'fallback' chain, the Key is looked up as is in the Source,
+// without the <name>FromEnv and <name>FromAuth indirection
+type FallbackStep struct {
+	Source ParsingOrder
+	Key    string
+}
+
+// String is a function that returns the step in the tag format, e.g. triggerMetadata:queueName
+func (fs FallbackStep) String() string {
+	return fmt.Sprintf("%s%s%s", fs.Source, fallbackStepSeparator, fs.Key)
+}
+
+// fallbackStepSeparator separates the source from the key in the 'fallback' chain steps
+const fallbackStepSeparator = ":"
+
+// fallbackEnvSource and fallbackLiteralSource are the 'fallback' chain sources on top of the parsing orders,
+// env is a shorthand for resolvedEnv and literal is the terminal step providing the default value
+const (
+	fallbackEnvSource     = "env"
+	fallbackLiteralSource = "literal"
+)
+
+// DurationMode is a type that represents how durations are adjusted to the 'durationUnit'
+type DurationMode string
+
+// Constants that represent how durations are adjusted to the 'durationUnit'
+const (
+	// DurationModeRound rounds the duration to the nearest multiple of the unit, halfway values away from zero, this is the default
+	DurationModeRound DurationMode = "round"
+	// DurationModeTruncate truncates the duration toward zero to a multiple of the unit
+	DurationModeTruncate DurationMode = "truncate"
+)
+
+// allowedDurationModeMap is a map with set of valid duration adjustment modes
+var allowedDurationModeMap = map[DurationMode]bool{
+	DurationModeRound:    true,
+	DurationModeTruncate: true,
+}
+
+// OnDuplicate is a type that represents how duplicate keys are handled when parsing maps
+type OnDuplicate string
+
+// Constants that represent how duplicate keys are handled when parsing maps
+const (
+	// OnDuplicateLast keeps the value of the last occurrence of the key, this is the default
+	OnDuplicateLast OnDuplicate = "last"
+	// OnDuplicateFirst keeps the value of the first occurrence of the key
+	OnDuplicateFirst OnDuplicate = "first"
+	// OnDuplicateError rejects the value if any key occurs more than once
+	OnDuplicateError OnDuplicate = "error"
+)
+
+// allowedOnDuplicateMap is a map with set of valid duplicate key handling modes
+var allowedOnDuplicateMap = map[OnDuplicate]bool{
+	OnDuplicateLast:  true,
+	OnDuplicateFirst: true,
+	OnDuplicateError: true,
+}
+
+// Params is a struct that represents the parameter list that can be used in the keda tag
+type Params struct {
+	// FieldName is the name of the field in the struct
+	FieldName string
+
+	// Name is the 'name' tag parameter defining the key in triggerMetadata, resolvedEnv or authParams
+	Name string
+
+	// KeyPrefix is the 'keyPrefix' tag parameter prepended to all the names when looking up the parameter,
+	// the first letter of the name is capitalized, e.g. keyPrefix=authTls with name=cert looks up authTlsCert
+	KeyPrefix string
+
+	// AltNames are the additional names from the 'name' tag parameter, e.g. name=a;b;c, tried in order
+	// after Name within each of the sources from the parsing order
+	AltNames []string
+
+	// Optional is the 'optional' tag parameter defining if the parameter is optional
+	Optional bool
+
+	// Order is the 'order' tag parameter defining the parsing order in which the parameter is looked up
+	// in the triggerMetadata, resolvedEnv, authParams or custom source maps, parameters without the tag
+	// are looked up in triggerMetadata, resolvedEnv and authParams unless ScalerConfig.ParsingOrderOverride is set
+	Order []ParsingOrder
+
+	// Default is the 'default' tag parameter defining the default value of the parameter if it's not found
+	// in any of the maps from ParsingOrder
+	Default string
+
+	// Deprecated is the 'deprecated' tag parameter, if the map contain this parameter, it is considered
+	// as an error and the DeprecatedMessage should be returned to the user
+	Deprecated string
+
+	// RemovedIn is the 'removedIn' tag parameter with the version in which the deprecated parameter is going
+	// to be removed, it's appended to the DeprecatedMessage, e.g. removedIn=2.20
+	RemovedIn string
+
+	// Negate is the 'negate' tag parameter defining that the parsed boolean value, including the default,
+	// is inverted before it's assigned, e.g. disableFoo=true populates EnableFoo with false
+	Negate bool
+
+	// OnDuplicate is the 'onDuplicate' tag parameter defining how duplicate keys are handled when parsing maps,
+	// one of last (default), first or error
+	OnDuplicate OnDuplicate
+
+	// Doc is the 'doc' tag parameter with the human readable description of the parameter, it doesn't affect
+	// the parsing and is only surfaced through DescribeConfig, e.g. doc='name of the queue, case sensitive'
+	Doc string
+
+	// AllowEmpty is the 'allowEmpty' tag parameter defining that a present key with an empty value
+	// counts as a provided value instead of being treated as unset
+	AllowEmpty bool
+
+	// DefaultOnEmpty is the 'defaultOnEmpty' tag parameter defining that the default value is also used
+	// when the resolved value is present but empty, this only matters together with allowEmpty because
+	// otherwise empty values are treated as unset and the default applies anyway
+	DefaultOnEmpty bool
+
+	// RequiredKeys is the 'requiredKeys' tag parameter defining the keys that must be present in the parsed map
+	RequiredKeys []string
+
+	// RowSeparator is the 'rowSeparator' tag parameter defining the separator of the rows of a two dimensional slice,
+	// ';' is used if not provided
+	RowSeparator string
+
+	// ColumnSeparator is the 'columnSeparator' tag parameter defining the separator of the columns within a row
+	// of a two dimensional slice, ',' is used if not provided, use quotes for separators clashing with the tag
+	// syntax, e.g. columnSeparator=','
+	ColumnSeparator string
+
+	// Rectangular is the 'rectangular' tag parameter defining that all rows of a two dimensional slice
+	// must have the same number of columns
+	Rectangular bool
+
+	// Template is the 'template' tag parameter defining that the parsed string value is expanded with text/template
+	// once all the fields of the struct are parsed, the struct is the template data, e.g. /api/{{.Version}}/metrics
+	Template bool
+
+	// MaxElems is the 'maxElems' tag parameter defining the maximum number of elements of a slice or map parameter,
+	// for two dimensional slices the limit applies to the number of rows and to the total number of cells,
+	// ScalerConfig.MaxElems or 10000 is used if not provided
+	MaxElems int
+
+	// Rate is the 'rate' tag parameter defining that the value is a byte rate with the /s suffix parsed
+	// into bytes per second, e.g. 10MB/s or 500KiB/s, the field has to be an integer
+	Rate bool
+
+	// Lazy is the 'lazy' tag parameter defining that the resolved value is wrapped in a closure assigned
+	// to a func() string or func() (string, error) field, so the value is only materialized when called
+	Lazy bool
+
+	// Quantity is the 'quantity' tag parameter defining that the value is a Kubernetes quantity parsed
+	// into its integer value, e.g. 1Mi or 2k, the field has to be an integer, for maps it applies to the values
+	Quantity bool
+
+	// MultipleOf is the 'multipleOf' tag parameter defining the step the parsed integer value has to be
+	// a multiple of, e.g. multipleOf=5 accepts 0, 5 or 10 but rejects 7
+	MultipleOf int64
+
+	// TrueToken and FalseToken are the 'trueToken' and 'falseToken' tag parameters defining the case insensitive
+	// words a bool field is parsed from instead of the strconv.ParseBool values, e.g. trueToken=on, falseToken=off
+	TrueToken  string
+	FalseToken string
+
+	// TypeHints is the 'typeHints' tag parameter defining that the elements of an any or []any field carry
+	// a type prefix, i: for int64, f: for float64, b: for bool and s: for string, e.g. i:5,s:hello,b:true
+	TypeHints bool
+
+	// Validators are the 'validate' tag parameter with the names of the validators registered with
+	// RegisterValidator, they are run in order after the value is assigned, e.g. validate=nonNegative;even
+	Validators []string
+
+	// DurationUnit is the 'durationUnit' tag parameter defining the precision of the parsed duration, the value
+	// is rounded or truncated to a multiple of the unit according to DurationMode, e.g. durationUnit=s
+	DurationUnit time.Duration
+
+	// DurationMode is the 'durationMode' tag parameter defining how the duration is adjusted to the DurationUnit,
+	// one of round (default) or truncate
+	DurationMode DurationMode
+
+	// WholeMetadata is the 'wholeMetadata' tag parameter defining that the map[string]string field receives
+	// a copy of the whole triggerMetadata, e.g. for logging or forwarding, the tag can't be combined with a name
+	WholeMetadata bool
+
+	// HumanInt is the 'humanInt' tag parameter defining that the integer value may group the digits with
+	// single underscores the same as Go literals, e.g. 1_000_000, the field has to be an integer
+	HumanInt bool
+
+	// Fallback is the 'fallback' tag parameter defining the ordered chain of source:key steps the value is resolved
+	// from instead of the name and order, the optional terminal literal:value step provides the Default,
+	// e.g. fallback=triggerMetadata:queue;env:QUEUE_NAME;literal:default
+	Fallback []FallbackStep
+
+	// Ratio is the 'ratio' tag parameter defining that the value is a ratio in the a:b format, e.g. 3:2, parsed
+	// into a/b for float fields or into the two elements of a [2]T array or a struct with two exported fields
+	Ratio bool
+
+	// PresenceTrue is the 'presenceTrue' tag parameter defining that the bool field is true whenever the key is present,
+	// regardless of the value, and false only when it's absent, the presence takes precedence over an explicit "false"
+	// value and empty values count as present the same as with the 'allowEmpty' tag
+	PresenceTrue bool
+
+	// DefaultUnit is the 'defaultUnit' tag parameter defining the unit of the unit-less numbers parsed into a duration,
+	// e.g. with defaultUnit=s both 30 and 30s are parsed as 30 seconds
+	DefaultUnit time.Duration
+
+	// Percent is the 'percent' tag parameter defining that the value is a signed percentage with the % suffix
+	// parsed into a float field, one of fraction (default) or whole, e.g. -25% is -0.25 or -25 and +10% is 0.1 or 10
+	Percent PercentMode
+
+	// CSV is the 'csv' tag parameter defining that the slice value is a single record parsed with encoding/csv,
+	// so the elements can be quoted to contain commas, newlines or doubled quotes, e.g. a,"b,c","say ""hi"""
+	CSV bool
+
+	// TrimCutset is the 'trimCutset' tag parameter with the characters trimmed from both ends of the value before
+	// it's converted, for slices and maps it applies to the whole value and to each of the elements, e.g. trimCutset=[]
+	// or trimCutset='"[] ' where the quotes are needed for the separators and the leading or trailing spaces
+	TrimCutset string
+
+	// Comparisons are the 'lessThan', 'lessThanOrEqual', 'greaterThan' and 'greaterThanOrEqual' tag parameters with
+	// the Go names of the sibling fields of the same numeric type the value is compared with once all the fields of the
+	// struct are parsed, nil pointers are skipped, e.g. lessThanOrEqual=MaxReplicas
+	Comparisons []FieldComparison
+
+	// ExclusiveSet is the 'exclusiveSet' tag parameter with the Go names of the sibling fields that can't be set
+	// together with the field, it's checked once all the fields of the struct are parsed and the zero values count
+	// as not set, so it's enough to tag one side of the optional fields, e.g. exclusiveSet=ApplicationID;ApplicationKey
+	ExclusiveSet []string
+
+	// RequiredIf is the 'requiredIf' tag parameter with the conditions on the sibling fields making the optional field
+	// required, it's checked once all the fields of the struct are parsed, the condition is either the Go name of
+	// the field that is set or the name and the value of the field, e.g. requiredIf=ApplicationKey;RunnerScope:repo
+	RequiredIf []FieldCondition
+
+	// Gated is the 'gated' tag parameter with the name of the feature gate from ScalerConfig.Gates the parameter
+	// depends on, with the gate disabled the field is skipped and a warning is logged if the parameter is set
+	Gated string
+
+	// StripPrefix is the 'stripPrefix' tag parameter with the case insensitive prefix removed from the resolved value
+	// if present, e.g. stripPrefix='Bearer ' turns both "Bearer token" and "token" into "token"
+	StripPrefix string
+
+	// RenamedFrom is the 'renamedFrom' tag parameter with the deprecated previous name of the parameter, the value of
+	// the previous name is used with a warning when the parameter isn't set, when both are set the parameter wins
+	// and the warning is only logged if the parsed values differ, e.g. renamedFrom=queueLength
+	RenamedFrom string
+
+	// DecimalComma is the 'decimalComma' tag parameter defining that the comma is the decimal separator of the value,
+	// e.g. 1,5 is 1.5, it only applies to scalar float fields as the comma separates the elements of slices and maps
+	DecimalComma bool
+
+	// CheckHosts is set by the 'allowHosts' tag parameter defining that the value is a URL with the host matching
+	// AllowHosts and ScalerConfig.AllowedHosts when set, the bare tag only applies ScalerConfig.AllowedHosts
+	CheckHosts bool
+
+	// AllowHosts is the 'allowHosts' tag parameter with the allowed URL hosts, the *. prefix matches any subdomain,
+	// e.g. allowHosts=api.example.com;*.internal
+	AllowHosts []string
+
+	// Clamp is the 'clamp' tag parameter with the min and max bound the parsed numeric value is clamped into instead
+	// of being rejected, an empty bound is unlimited, e.g. clamp=1:100 turns 200 into 100
+	Clamp []float64
+
+	// Separator is the 'separator' tag parameter defining the separator of the slice, set and map elements,
+	// the ElemSeparatorProvider of the struct or ScalerConfig.ElemSeparator is used if not provided and ','
+	// if none of them is set, use quotes for separators clashing with the tag syntax, e.g. separator='|'
+	Separator string
+
+	// Unique is the 'unique' tag parameter defining that the parsed slice elements have to be distinct,
+	// e.g. a list of partition IDs, duplicates are rejected with an error naming the repeated element
+	Unique bool
+
+	// JSONPath is the 'jsonPath' tag parameter defining the path of the value within the JSON object of the parameter,
+	// object keys and array indexes are supported, e.g. jsonPath=$.auth.token or jsonPath=$.brokers[0].port
+	JSONPath string
+
+	// Schemes is the 'schemes' tag parameter with the allowed schemes of the url.URL field, any scheme is allowed
+	// if not provided, e.g. schemes=https;http
+	Schemes []string
+
+	// Enum is the 'enum' tag parameter with the allowed values of the parameter, each element is checked
+	// for slice and set fields, e.g. enum=earliest;latest
+	Enum []string
+
+	// IgnoreCase is the 'ignoreCase' tag parameter making the 'enum' check case-insensitive, the matched values
+	// are replaced with their spelling from the 'enum' tag, e.g. enum=US;EU, ignoreCase accepts "eu" as "EU"
+	IgnoreCase bool
+
+	// Min is the 'min' tag parameter defining the inclusive lower bound of the numeric value, e.g. min=1
+	Min *float64
+
+	// Max is the 'max' tag parameter defining the inclusive upper bound of the numeric value, e.g. max=100
+	Max *float64
+
+	// Prefix is the 'prefix' tag parameter of the nested struct prepended to the names of all its parameters
+	// the same as 'keyPrefix', e.g. prefix=tls with name=cert in the nested struct looks up tlsCert,
+	// prefixes of the nested structs within the nested struct are joined, e.g. proxyTlsCert
+	Prefix string
+
+	// KeyValSeparator is the 'keyValSeparator' tag parameter defining the separator of the keys and the values
+	// of map and key-value pair elements, '=' is used if not provided, e.g. keyValSeparator=:
+	KeyValSeparator string
+
+	// FromAuth is the 'fromAuth' tag parameter allowing the <name>FromAuth triggerMetadata key to redirect
+	// the authParams lookup to another key, only string fields can opt in so that the redirected authParams
+	// value never ends up in a parsing error, e.g. fromAuth
+	FromAuth bool
+
+	// Sensitive is the 'sensitive' tag parameter replacing the value with a placeholder in all the parsing errors,
+	// intended for credentials, e.g. sensitive
+	Sensitive bool
+}
+
+// parseClamp is a function that parses the 'clamp' tag value in the min:max format, an empty bound is unlimited
+func parseClamp(val string) ([]float64, error) {
+	minStr, maxStr, found := strings.Cut(strings.TrimSpace(val), ":")
+	clamp := []float64{math.Inf(-1), math.Inf(1)}
+	for i, bound := range []string{minStr, maxStr} {
+		if bound = strings.TrimSpace(bound); bound == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(bound, 64)
+		if err != nil || math.IsNaN(parsed) {
+			found = false
+			break
+		}
+		clamp[i] = parsed
+	}
+	if !found || clamp[0] > clamp[1] {
+		return nil, fmt.Errorf("invalid clamp value %q, has to be in format min:max with min not greater than max", val)
+	}
+	return clamp, nil
+}
+
+// separators is a function that returns the separator of the elements and the separator of the slice values
+// of maps, the nested separator is ',' when the elements are separated by ';', e.g. a=1,2;b=3
+func (p Params) separators() (string, string) {
+	switch p.Separator {
+	case "", elemSeparator:
+		return elemSeparator, nestedElemSeparator
+	case nestedElemSeparator:
+		return nestedElemSeparator, elemSeparator
+	default:
+		return p.Separator, nestedElemSeparator
+	}
+}
+
+// keyValSeparator is a function that returns the separator of the keys and the values of map and pair elements
+func (p Params) keyValSeparator() string {
+	if p.KeyValSeparator != "" {
+		return p.KeyValSeparator
+	}
+	return elemKeyValSeparator
+}
+
+// maxElems is a function that returns the effective maximum number of elements of a slice or map parameter
+func (p Params) maxElems() int {
+	if p.MaxElems > 0 {
+		return p.MaxElems
+	}
+	return defaultMaxElems
+}
+
+// checkElemCount is a function that rejects values with more elements than allowed for the parameter
+func checkElemCount(params Params, count int) error {
+	if count > params.maxElems() {
+		return fmt.Errorf("has %d elements, exceeds the maximum of %d", count, params.maxElems())
+	}
+	return nil
+}
+
+// IsNested is a function that returns true if the parameter is a nested structure, i.e. the tag has no name,
+// e.g. `keda:""`, the fields of the nested structure are parsed with the same ScalerConfig
+func (p Params) IsNested() bool {
+	return p.Name == "" && len(p.Fallback) == 0
+}
+
+// joinKeyPrefix is a function that joins the outer and the inner key prefix capitalizing the first letter of the inner one,
+// e.g. proxy and tls is proxyTls
+func joinKeyPrefix(outer, inner string) string {
+	if outer == "" || inner == "" {
+		return outer + inner
+	}
+	return outer + strings.ToUpper(inner[:1]) + inner[1:]
+}
+
+// Names is a function that returns all the names of the parameter in the order they are tried,
+// including the 'keyPrefix' if set
+func (p Params) Names() []string {
+	names := append([]string{p.Name}, p.AltNames...)
+	if p.KeyPrefix == "" {
+		return names
+	}
+	for i, name := range names {
+		if name != "" {
+			names[i] = joinKeyPrefix(p.KeyPrefix, name)
+		}
+	}
+	return names
+}
+
+// DisplayName is a function that returns the parameter name as used in the error messages
+func (p Params) DisplayName() string {
+	if p.Name == "" && len(p.Fallback) > 0 {
+		steps := make([]string, len(p.Fallback))
+		for i, step := range p.Fallback {
+			steps[i] = step.String()
+		}
+		return strings.Join(steps, tagValueSeparator)
+	}
+	return strings.Join(p.Names(), tagValueSeparator)
+}
+
+// FieldDisplayName is a function that returns the Go field name along with the parameter name
+// as used in the error messages of the parsed values, e.g. field StringVal (param "stringVal")
+func (p Params) FieldDisplayName() string {
+	return fmt.Sprintf("field %s (param %q)", p.FieldName, p.DisplayName())
+}
+
+// displayValue is a function that formats the value for the error messages, the value of the 'sensitive'
+// parameter is replaced with the placeholder
+func (p Params) displayValue(format string, val any) string {
+	if p.Sensitive {
+		return sensitiveValuePlaceholder
+	}
+	return fmt.Sprintf(format, val)
+}
+
+// redactSensitive is a function that replaces the error of the 'sensitive' parameter with a generic one
+// if the message still contains any of its values, e.g. when returned by a validator or the value interceptor
+func redactSensitive(params Params, err error, values []string) error {
+	if err == nil || !params.Sensitive {
+		return err
+	}
+	msg := err.Error()
+	for _, val := range values {
+		if val == "" {
+			continue
+		}
+		quoted := strconv.Quote(val)
+		if strings.Contains(msg, val) || strings.Contains(msg, quoted[1:len(quoted)-1]) {
+			return fmt.Errorf("%s value %s is invalid", params.FieldDisplayName(), sensitiveValuePlaceholder)
+		}
+	}
+	return err
+}
+
+// IsDeprecated is a function that returns true if the parameter is deprecated
+func (p Params) IsDeprecated() bool {
+	return p.Deprecated != ""
+}
+
+// DeprecatedMessage is a function that returns the optional deprecated message if the parameter is deprecated
+func (p Params) DeprecatedMessage() string {
+	var details []string
+	if p.Deprecated != deprecatedTag {
+		details = append(details, p.Deprecated)
+	}
+	if p.RemovedIn != "" {
+		details = append(details, fmt.Sprintf("removed in %s", p.RemovedIn))
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(": %s", strings.Join(details, tagValueSeparator+" "))
+}
+
+// TypedConfig is a function that is used to unmarshal the TriggerMetadata, ResolvedEnv, AuthParams and CustomSources
+// populating the provided typedConfig where structure fields along with complementary field tags define
+// declaratively the parsing rules
+func (sc *ScalerConfig) TypedConfig(typedConfig any) (err error) {
+	defer recoverPanic(&err)
+	err = sc.parseTypedConfig(context.Background(), typedConfig, false)
+	return
+}
+
+// ParseConfig is a function that allocates the T, populates it with TypedConfig and returns it,
+// nil is returned along with the error if parsing fails, e.g. meta, err := ParseConfig[kafkaMetadata](config)
+func ParseConfig[T any](sc *ScalerConfig) (*T, error) {
+	typedConfig := new(T)
+	if err := sc.TypedConfig(typedConfig); err != nil {
+		return nil, err
+	}
+	return typedConfig, nil
+}
+
+// recoverPanic is a function deferred by the typed config entry points that turns a panic into the error,
+// this shouldn't happen, but calling certain reflection functions may result in panic and if it does,
+// it's better to return an error with stacktrace and reject parsing config rather than crashing KEDA
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("failed to parse typed config, resulted in panic: %v\n%s", r, debug.Stack())
+	}
+}
+
+// TypedConfigContext is a function that works like TypedConfig but stops parsing once the ctx is done,
+// the returned error wraps the context error, e.g. context.Canceled or context.DeadlineExceeded
+func (sc *ScalerConfig) TypedConfigContext(ctx context.Context, typedConfig any) (err error) {
+	defer recoverPanic(&err)
+	err = sc.parseTypedConfig(ctx, typedConfig, false)
+	return
+}
+
+// TypedConfigInto is a function that works like TypedConfig but merges the parsed values onto an already
+// populated typedConfig, which allows composing the config from several layers, e.g. operator defaults
+// followed by per-object overrides, by calling it once per ScalerConfig.
+//
+// The preserveExisting semantic applies to parameters absent from this ScalerConfig:
+//   - fields holding a non-zero value keep it, neither the 'default' tag nor the missing required
+//     parameter error applies to them
+//   - fields holding the zero value are handled as in TypedConfig
+//
+// Parameters present in this ScalerConfig always overwrite the field. As a zero value can't be told
+// apart from an unset one, use pointer fields for parameters where an explicit zero value from a
+// previous layer has to be preserved.
+func (sc *ScalerConfig) TypedConfigInto(typedConfig any) (err error) {
+	defer recoverPanic(&err)
+	err = sc.parseTypedConfig(context.Background(), typedConfig, true)
+	return
+}
+
+// ApplyDefaults is a function that populates the typedConfig only with the values of the 'default' tags, e.g. for
+// rendering the default configuration, the sources aren't looked up, the fields without the 'default' tag are left
+// as they are and neither the missing required parameter errors nor the CustomValidator apply
+func (sc *ScalerConfig) ApplyDefaults(typedConfig any) (err error) {
+	defer recoverPanic(&err)
+	defaultsConfig := *sc
+	defaultsConfig.TriggerMetadata = nil
+	defaultsConfig.ResolvedEnv = nil
+	defaultsConfig.AuthParams = nil
+	defaultsConfig.CustomSources = nil
+	defaultsConfig.report = nil
+	defaultsConfig.defaultsOnly = true
+	err = defaultsConfig.parseTypedConfig(context.Background(), typedConfig, false)
+	return
+}
+
+// ConfigReport is the result of TypedConfigWithReport with the declared parameters that weren't found in any source
+// and the triggerMetadata keys that weren't consumed by any parameter, e.g. for debugging why a value didn't take effect
+type ConfigReport struct {
+	// NotFound are the declared parameters that weren't found in any source, including the ones using the default value
+	NotFound []Params
+
+	// Unconsumed are the sorted triggerMetadata keys that weren't consumed by any parameter,
+	// including the <name>FromEnv and <name>FromAuth keys
+	Unconsumed []string
+}
+
+// configReport is the state collected while parsing the typed config for the ConfigReport
+type configReport struct {
+	consumed map[string]bool
+	notFound []Params
+}
+
+// consume is a function that marks the triggerMetadata key as consumed, it's a no-op without the report
+func (r *configReport) consume(po ParsingOrder, key string) {
+	if r != nil && po == TriggerMetadata {
+		r.consumed[key] = true
+	}
+}
+
+// TypedConfigWithReport is a function that works like TypedConfig and also returns the ConfigReport, the tracking
+// is opt-in through this function so TypedConfig doesn't pay for it, the report is returned even if parsing fails
+func (sc *ScalerConfig) TypedConfigWithReport(typedConfig any) (report *ConfigReport, err error) {
+	defer recoverPanic(&err)
+	// the report is tracked on a copy so concurrent parsing of the same ScalerConfig isn't affected
+	reportConfig := *sc
+	reportConfig.report = &configReport{consumed: map[string]bool{}}
+	err = reportConfig.parseTypedConfig(context.Background(), typedConfig, false)
+	report = &ConfigReport{NotFound: reportConfig.report.notFound}
+	for _, key := range sortedKeys(sc.TriggerMetadata) {
+		if !reportConfig.report.consumed[key] {
+			report.Unconsumed = append(report.Unconsumed, key)
+		}
+	}
+	return
+}
+
+// MissingParam is the data of ScalerConfig.MissingErrorTemplate describing the missing required parameter
+type MissingParam struct {
+	// Field is the display name of the field, e.g. field Host (param "host")
+	Field string
+	// Name is the name of the parameter, the names are joined with ';' for multiple names or fallback steps
+	Name string
+	// Order is the parsing order the parameter was looked up in, e.g. [triggerMetadata] or fallback [env:HOST]
+	Order string
+}
+
+// defaultMissingErrorTemplate is the missing required parameter error used without ScalerConfig.MissingErrorTemplate
+const defaultMissingErrorTemplate = "missing required {{.Field}} in {{.Order}}"
+
+// missingError is a function that returns the missing required parameter error rendered with the
+// ScalerConfig.MissingErrorTemplate, the default message is used if the template fails
+func (sc *ScalerConfig) missingError(params Params) error {
+	missing := MissingParam{Field: params.FieldDisplayName(), Name: params.DisplayName(), Order: fmt.Sprint(params.Order)}
+	if len(params.Fallback) > 0 {
+		missing.Order = fmt.Sprintf("fallback %v", params.Fallback)
+	}
+	msg, err := renderMissingError(sc.MissingErrorTemplate, missing)
+	if err != nil {
+		msg, _ = renderMissingError("", missing)
+	}
+	return errors.New(msg)
+}
+
+// renderMissingError is a function that renders the missing required parameter error template,
+// the defaultMissingErrorTemplate is used for the empty template
+func renderMissingError(text string, missing MissingParam) (string, error) {
+	if text == "" {
+		text = defaultMissingErrorTemplate
+	}
+	tmpl, err := template.New("missingError").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, missing); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// GetString is a function that returns the value of a single parameter resolved with the same rules as the typed config,
+// the parameter is looked up in triggerMetadata when no parsing order is provided,
+// the bool result reports whether the parameter was found
+func (sc *ScalerConfig) GetString(name string, order ...ParsingOrder) (string, bool, error) {
+	return getParam[string](sc, name, order)
+}
+
+// GetStringSource is a function that works like GetString but also returns the parsing order the parameter
+// was found in, e.g. to tell the value from triggerMetadata apart from the same value in authParams
+func (sc *ScalerConfig) GetStringSource(name string, order ...ParsingOrder) (string, ParsingOrder, bool, error) {
+	if len(order) == 0 {
+		order = []ParsingOrder{TriggerMetadata}
+	}
+	for _, po := range order {
+		val, exists, err := getParam[string](sc, name, []ParsingOrder{po})
+		if exists || err != nil {
+			return val, po, exists, err
+		}
+	}
+	return "", "", false, nil
+}
+
+// GetInt is a function that returns the value of a single parameter converted to int,
+// the bool result reports whether the parameter was found
+func (sc *ScalerConfig) GetInt(name string, order ...ParsingOrder) (int, bool, error) {
+	return getParam[int](sc, name, order)
+}
+
+// GetBool is a function that returns the value of a single parameter converted to bool,
+// the bool result reports whether the parameter was found
+func (sc *ScalerConfig) GetBool(name string, order ...ParsingOrder) (bool, bool, error) {
+	return getParam[bool](sc, name, order)
+}
+
+// GetDuration is a function that returns the value of a single parameter converted to time.Duration,
+// the bool result reports whether the parameter was found
+func (sc *ScalerConfig) GetDuration(name string, order ...ParsingOrder) (time.Duration, bool, error) {
+	return getParam[time.Duration](sc, name, order)
+}
+
+// getParam is a function that resolves a single parameter and converts it to the requested type
+func getParam[T any](sc *ScalerConfig, name string, order []ParsingOrder) (T, bool, error) {
+	var val T
+	ctx := context.Background()
+	params := singleParam(name, order)
+	valFromConfig, exists, err := sc.interceptedParamValue(ctx, params)
+	if err != nil {
+		return val, true, fmt.Errorf("unable to set param %q value interceptor: %w", name, err)
+	}
+	if !exists {
+		return val, false, nil
+	}
+	if err := setConfigValueHelper(ctx, params, valFromConfig, reflect.ValueOf(&val).Elem()); err != nil {
+		return val, true, fmt.Errorf("unable to set param %q value %q: %w", name, valFromConfig, err)
+	}
+	return val, true, nil
+}
+
+// singleParam is a function that returns the Params for a single parameter read without a typed config struct
+func singleParam(name string, order []ParsingOrder) Params {
+	if len(order) == 0 {
+		order = []ParsingOrder{TriggerMetadata}
+	}
+	return Params{FieldName: name, Name: name, Order: order}
+}
+
+// parseTypedConfig is a function that is used to unmarshal the TriggerMetadata, ResolvedEnv, AuthParams and CustomSources
+// with preserveExisting, fields with non-zero values are kept when the parameter is absent
+func (sc *ScalerConfig) parseTypedConfig(ctx context.Context, typedConfig any, preserveExisting bool) error {
+	t := reflect.TypeOf(typedConfig)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return fmt.Errorf("typedConfig must be a pointer")
+	}
+	if t.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("typedConfig must be a pointer to a struct")
+	}
+	if _, err := renderMissingError(sc.MissingErrorTemplate, MissingParam{}); err != nil {
+		return fmt.Errorf("invalid missing error template: %w", err)
+	}
+	if sc.ElemSeparator == elemKeyValSeparator {
+		return fmt.Errorf("invalid elem separator %q, clashes with the key-value separator", sc.ElemSeparator)
+	}
+	for _, po := range sc.ParsingOrderOverride {
+		if name, isCustom := po.customSourceName(); (!isCustom || name == "") && !allowedParsingOrderMap[po] {
+			return fmt.Errorf("unknown parsing order override value %s, has to be one of %v or %s<name>", po, sortedKeys(allowedParsingOrderMap), customParsingOrderPrefix)
+		}
+	}
+	return sc.parseTypedConfigValue(ctx, reflect.ValueOf(typedConfig).Elem(), preserveExisting)
+}
+
+// parseTypedConfigValue is a function that populates the fields of the struct value,
+// this can be called recursively to parse nested structures
+func (sc *ScalerConfig) parseTypedConfigValue(ctx context.Context, v reflect.Value, preserveExisting bool) error {
+	t := v.Type()
+	if v.CanAddr() && v.Addr().CanInterface() {
+		if provider, ok := v.Addr().Interface().(ElemSeparatorProvider); ok {
+			// the copy carries the separator to the fields and the nested structs of this struct only
+			structConfig := *sc
+			structConfig.ElemSeparator = provider.ElemSeparator()
+			sc = &structConfig
+		}
+	}
+	errs := []error{}
+	templated := map[int]Params{}
+	compared := map[int]Params{}
+	related := map[int]Params{}
+	parsed := map[string]Params{}
+	failed := map[string]bool{}
+	wholeMetadataField := ""
+	// parameters with variables in the names are deferred until the fields they reference are parsed
+	paramFields := map[string]int{}
+	deferred := map[int]Params{}
+	setField := func(i int, tagParams Params) {
+		if err := sc.checkCustomSources(tagParams); err != nil && !sc.defaultsOnly {
+			errs = append(errs, err)
+			failed[tagParams.FieldName] = true
+			return
+		}
+		if err := sc.setValue(ctx, v.Field(i), tagParams, preserveExisting); err != nil {
+			errs = append(errs, err)
+			failed[tagParams.FieldName] = true
+			return
+		}
+		if tagParams.Template {
+			templated[i] = tagParams
+		}
+		if len(tagParams.Comparisons) > 0 {
+			compared[i] = tagParams
+		}
+		if len(tagParams.ExclusiveSet) > 0 || len(tagParams.RequiredIf) > 0 {
+			related[i] = tagParams
+		}
+		parsed[tagParams.FieldName] = tagParams
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("parsing typed config aborted: %w", err)
+		}
+		fieldType := t.Field(i)
+		fieldValue := v.Field(i)
+		tag, exists := fieldType.Tag.Lookup("keda")
+		if !exists || tag == skipTag {
+			continue
+		}
+		tagParams, err := paramsFromTag(tag, fieldType)
+		if err != nil {
+			errs = append(errs, err)
+			failed[fieldType.Name] = true
+			continue
+		}
+		if len(tagParams.Fallback) == 0 {
+			tagParams.Order = sc.overrideParsingOrder(tagParams.Order)
+		}
+		if tagParams.Separator == "" {
+			tagParams.Separator = sc.ElemSeparator
+		}
+		tagParams.KeyPrefix = joinKeyPrefix(sc.keyPrefix, tagParams.KeyPrefix)
+		if tagParams.Gated != "" && !sc.Gates[tagParams.Gated] {
+			if _, exists := sc.configParamValue(tagParams); !tagParams.IsNested() && exists {
+				sc.Logger.Info("parameter ignored, feature gate is disabled", "parameter", tagParams.DisplayName(), "gate", tagParams.Gated)
+			}
+			continue
+		}
+		if tagParams.WholeMetadata && sc.defaultsOnly {
+			continue
+		}
+		if tagParams.WholeMetadata {
+			if wholeMetadataField != "" {
+				errs = append(errs, fmt.Errorf("fields %s and %s both use '%s' tag, only one is allowed", wholeMetadataField, tagParams.FieldName, wholeMetadataTag))
+				continue
+			}
+			wholeMetadataField = tagParams.FieldName
+			if fieldValue.Type() != reflect.TypeOf(map[string]string{}) {
+				errs = append(errs, fmt.Errorf("field %s uses '%s' tag, expected map[string]string field, has type %v", tagParams.FieldName, wholeMetadataTag, fieldValue.Type()))
+				continue
+			}
+			fieldValue.Set(reflect.ValueOf(maps.Clone(sc.TriggerMetadata)))
+			for key := range sc.TriggerMetadata {
+				sc.report.consume(TriggerMetadata, key)
+			}
+			continue
+		}
+		if tagParams.IsNested() {
+			if err := sc.setNestedValue(ctx, fieldValue, tagParams, preserveExisting); err != nil {
+				if ctx.Err() != nil {
+					return err
+				}
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if tagParams.hasNameVariables() {
+			deferred[i] = tagParams
+			continue
+		}
+		paramFields[tagParams.Name] = i
+		setField(i, tagParams)
+	}
+	for i := 0; i < t.NumField(); i++ {
+		tagParams, ok := deferred[i]
+		if !ok {
+			continue
+		}
+		expanded, ok, err := sc.expandNameVariables(v, tagParams, paramFields, failed)
+		if err != nil || !ok {
+			if err != nil {
+				errs = append(errs, err)
+			}
+			failed[tagParams.FieldName] = true
+			continue
+		}
+		setField(i, expanded)
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if params, ok := templated[i]; ok {
+			if err := expandTemplate(v, v.Field(i), params); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if params, ok := compared[i]; ok {
+			for _, comparison := range params.Comparisons {
+				if failed[comparison.Field] {
+					continue
+				}
+				if err := compareFields(v, v.Field(i), params, comparison); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if params, ok := related[i]; ok && !sc.defaultsOnly {
+			if err := checkRelatedFields(v, v.Field(i), params, parsed, failed); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if v.CanAddr() && v.Addr().CanInterface() && !sc.defaultsOnly {
+		switch validator := v.Addr().Interface().(type) {
+		case CustomValidatorContext:
+			if err := validator.ValidateContext(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		case CustomValidator:
+			if err := validator.Validate(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// setNestedValue is a function that parses the nested struct field, pointers to structs are allocated
+func (sc *ScalerConfig) setNestedValue(ctx context.Context, field reflect.Value, params Params, preserveExisting bool) error {
+	for field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		field = field.Elem()
+	}
+	if field.Kind() != reflect.Struct {
+		return fmt.Errorf("nested parameter %q must be a struct, has kind %q", params.FieldName, field.Kind())
+	}
+	if params.Prefix != "" {
+		// the copy carries the prefix to the fields and the nested structs of this struct only
+		prefixedConfig := *sc
+		prefixedConfig.keyPrefix = joinKeyPrefix(sc.keyPrefix, params.Prefix)
+		return prefixedConfig.parseTypedConfigValue(ctx, field, preserveExisting)
+	}
+	return sc.parseTypedConfigValue(ctx, field, preserveExisting)
+}
+
+// overrideParsingOrder is a function that returns the parsing order of the parameter after applying
+// ScalerConfig.ParsingOrderOverride and ScalerConfig.ReverseParsingOrder, the order from the 'order' tag
+// is only overridden when ScalerConfig.ForceParsingOrderOverride is set, parameters without any order
+// fall back to the defaultParsingOrder
+func (sc *ScalerConfig) overrideParsingOrder(order []ParsingOrder) []ParsingOrder {
+	if len(order) > 0 && !sc.ForceParsingOrderOverride {
+		return order
+	}
+	if len(sc.ParsingOrderOverride) > 0 {
+		order = sc.ParsingOrderOverride
+	}
+	if len(order) == 0 {
+		order = defaultParsingOrder
+	}
+	if sc.ReverseParsingOrder {
+		reversed := make([]ParsingOrder, len(order))
+		for i, po := range order {
+			reversed[len(order)-1-i] = po
+		}
+		order = reversed
+	}
+	return order
+}
+
+// checkCustomSources is a function that verifies all custom sources referenced in the parsing order exist
+func (sc *ScalerConfig) checkCustomSources(params Params) error {
+	order := slices.Clone(params.Order)
+	for _, step := range params.Fallback {
+		order = append(order, step.Source)
+	}
+	for _, po := range order {
+		name, isCustom := po.customSourceName()
+		if !isCustom {
+			continue
+		}
+		if _, ok := sc.CustomSources[name]; !ok {
+			return fmt.Errorf("parameter %q references unknown custom source %q, has to be one of %v", params.DisplayName(), name, sortedKeys(sc.CustomSources))
+		}
+	}
+	return nil
+}
+
+// setValue is a function that sets the value of the field based on the provided params,
+// with preserveExisting the field with non-zero value is kept as is when the parameter is absent
+func (sc *ScalerConfig) setValue(ctx context.Context, field reflect.Value, params Params, preserveExisting bool) (err error) {
+	if params.PresenceTrue {
+		params.AllowEmpty = true
+	}
+	var valFromConfig string
+	var exists bool
+	// the raw, intercepted and transformed values of the sensitive parameter must not leak through
+	// the errors of the underlying parsers and validators
+	var sensitiveValues []string
+	if params.Sensitive {
+		_, rawValue, _ := sc.lookupParamValue(params)
+		sensitiveValues = append(sensitiveValues, rawValue)
+		defer func() { err = redactSensitive(params, err, append(sensitiveValues, valFromConfig)) }()
+	}
+	valFromConfig, exists, err = sc.interceptedParamValue(ctx, params)
+	if err != nil {
+		return fmt.Errorf("%s value interceptor: %w", params.FieldDisplayName(), err)
+	}
+	if params.RenamedFrom != "" {
+		valFromConfig, exists, err = sc.resolveRenamed(ctx, field, params, valFromConfig, exists)
+		if err != nil {
+			return fmt.Errorf("%s renamed from %q: %w", params.FieldDisplayName(), params.RenamedFrom, err)
+		}
+	}
+	if params.Sensitive {
+		sensitiveValues = append(sensitiveValues, valFromConfig)
+	}
+	if exists && params.IsDeprecated() {
+		return fmt.Errorf("%s is deprecated%v", params.FieldDisplayName(), params.DeprecatedMessage())
+	}
+	if !exists && preserveExisting && !field.IsZero() {
+		return nil
+	}
+	if params.PresenceTrue {
+		if field.Kind() != reflect.Bool {
+			return fmt.Errorf("%s uses '%s' tag, expected bool field, has kind %q", params.FieldDisplayName(), presenceTrueTag, field.Kind())
+		}
+		field.SetBool(exists != params.Negate)
+		return nil
+	}
+	if params.MaxElems == 0 {
+		params.MaxElems = sc.MaxElems
+	}
+	structSlice := isStructSliceType(field.Type())
+	if structSlice && !exists {
+		elems, err := sc.indexedElems(params)
+		if err != nil {
+			return fmt.Errorf("%s %w", params.FieldDisplayName(), err)
+		}
+		if elems != nil {
+			return sc.setStructSlice(ctx, field, params, elems)
+		}
+	}
+	if !exists && sc.report != nil {
+		sc.report.notFound = append(sc.report.notFound, params)
+	}
+	if !exists && sc.defaultsOnly && params.Default == "" {
+		return nil
+	}
+	useDefault := (!exists && params.Default != "") || (exists && valFromConfig == "" && params.DefaultOnEmpty)
+	if useDefault {
+		defaultValue, err := sc.expandDefault(params.Default)
+		if err != nil {
+			return fmt.Errorf("%s default: %w", params.FieldDisplayName(), err)
+		}
+		exists = true
+		valFromConfig = defaultValue
+	}
+	if !exists && (params.Optional || params.IsDeprecated()) {
+		return nil
+	}
+	if !exists {
+		return sc.missingError(params)
+	}
+	if params.JSONPath != "" && !useDefault {
+		extracted, err := extractJSONPath(params.JSONPath, valFromConfig)
+		if err != nil {
+			return fmt.Errorf("%s %w", params.FieldDisplayName(), err)
+		}
+		valFromConfig = extracted
+	}
+	if params.StripPrefix != "" && len(valFromConfig) >= len(params.StripPrefix) && strings.EqualFold(valFromConfig[:len(params.StripPrefix)], params.StripPrefix) {
+		valFromConfig = valFromConfig[len(params.StripPrefix):]
+	}
+	if len(params.Schemes) > 0 {
+		fieldType := field.Type()
+		for fieldType.Kind() == reflect.Pointer || fieldType.Kind() == reflect.Slice {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType != urlType {
+			return fmt.Errorf("%s uses '%s' tag, expected url.URL field, has type %v", params.FieldDisplayName(), schemesTag, field.Type())
+		}
+	}
+	if params.DecimalComma {
+		fieldType := field.Type()
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() != reflect.Float32 && fieldType.Kind() != reflect.Float64 {
+			return fmt.Errorf("%s uses '%s' tag, expected float field, has kind %q", params.FieldDisplayName(), decimalCommaTag, fieldType.Kind())
+		}
+		valFromConfig = strings.Replace(valFromConfig, elemSeparator, ".", 1)
+	}
+	if len(params.Enum) > 0 {
+		if valFromConfig, err = checkEnum(params, valFromConfig, field.Type()); err != nil {
+			return fmt.Errorf("%s %w", params.FieldDisplayName(), err)
+		}
+	}
+	if structSlice {
+		elems, err := parseJSONElems(params, valFromConfig)
+		if err != nil {
+			return fmt.Errorf("unable to set %s value %s: %w", params.FieldDisplayName(), params.displayValue("%q", valFromConfig), err)
+		}
+		return sc.setStructSlice(ctx, field, params, elems)
+	}
+	if params.TypeHints && field.Type() != anyType && field.Type() != reflect.SliceOf(anyType) {
+		return fmt.Errorf("%s uses '%s' tag, expected any or []any field, has type %v", params.FieldDisplayName(), typeHintsTag, field.Type())
+	}
+	if params.Negate && field.Kind() != reflect.Bool {
+		return fmt.Errorf("%s uses 'negate' tag, expected bool field, has kind %q", params.FieldDisplayName(), field.Kind())
+	}
+	if err := setConfigValueHelper(ctx, params, valFromConfig, field); err != nil {
+		return fmt.Errorf("unable to set %s value %s: %w", params.FieldDisplayName(), params.displayValue("%q", valFromConfig), err)
+	}
+	if params.Negate {
+		field.SetBool(!field.Bool())
+	}
+	if params.Clamp != nil {
+		if err := sc.clampValue(params, field); err != nil {
+			return fmt.Errorf("%s %w", params.FieldDisplayName(), err)
+		}
+	}
+	if len(params.RequiredKeys) > 0 {
+		if err := checkRequiredKeys(ctx, params, field); err != nil {
+			return fmt.Errorf("%s %w", params.FieldDisplayName(), err)
+		}
+	}
+	if params.Min != nil || params.Max != nil {
+		if err := checkRange(params, field); err != nil {
+			return fmt.Errorf("%s %w", params.FieldDisplayName(), err)
+		}
+	}
+	if params.Unique {
+		if err := checkUnique(params, field); err != nil {
+			return fmt.Errorf("%s %w", params.FieldDisplayName(), err)
+		}
+	}
+	if params.MultipleOf != 0 {
+		if err := checkMultipleOf(params, field); err != nil {
+			return fmt.Errorf("%s %w", params.FieldDisplayName(), err)
+		}
+	}
+	if params.CheckHosts {
+		if err := sc.checkAllowedHosts(params, field); err != nil {
+			return fmt.Errorf("%s %w", params.FieldDisplayName(), err)
+		}
+	}
+	for _, name := range params.Validators {
+		validator, _ := lookupValidator(name)
+		if err := validator(valFromConfig, field); err != nil {
+			return fmt.Errorf("%s validator %q: %w", params.FieldDisplayName(), name, err)
+		}
+	}
+	return nil
+}
+
+// indexedElemSeparator separates the parameter name, the element index and the element parameter name
+// of the indexed slice elements, e.g. metrics.0.name
+const indexedElemSeparator = "."
+
+// isStructSliceType is a function that returns true for the slices of structs or pointers to structs
+// with keda tags, the elements are parsed from a JSON array or the indexed keys, e.g. metrics.0.name
+func isStructSliceType(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	elem := t.Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < elem.NumField(); i++ {
+		if tag, ok := elem.Field(i).Tag.Lookup("keda"); ok && tag != skipTag {
+			return true
+		}
+	}
+	return false
+}
+
+// indexedElems is a function that collects the parameters of the indexed slice elements, e.g. metrics.0.name
+// and metrics.1.name, from the first source and name with any indexed key, missing indices are nil elements
+func (sc *ScalerConfig) indexedElems(params Params) ([]map[string]string, error) {
+	for _, po := range params.Order {
+		m, ok := sc.sourceMap(po)
+		if !ok {
+			continue
+		}
+		for _, name := range params.Names() {
+			prefix := name + indexedElemSeparator
+			var elems []map[string]string
+			for _, key := range sortedKeys(m) {
+				rest, found := strings.CutPrefix(key, prefix)
+				if !found {
+					continue
+				}
+				idx, param, found := strings.Cut(rest, indexedElemSeparator)
+				i, err := strconv.Atoi(idx)
+				if !found || param == "" || err != nil || i < 0 {
+					return nil, fmt.Errorf("expected indexed key in format %s<index>%s<param>, got %q", prefix, indexedElemSeparator, key)
+				}
+				if err := checkElemCount(params, i+1); err != nil {
+					return nil, err
+				}
+				for len(elems) <= i {
+					elems = append(elems, nil)
+				}
+				if elems[i] == nil {
+					elems[i] = map[string]string{}
+				}
+				elems[i][param] = m[key]
+				sc.report.consume(po, key)
+			}
+			if elems != nil {
+				return elems, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// parseJSONElems is a function that parses the JSON array of objects into the parameters of the slice elements,
+// the string values are unquoted and the other values are kept as JSON, null elements are nil elements
+func parseJSONElems(params Params, valFromConfig string) ([]map[string]string, error) {
+	var rawElems []json.RawMessage
+	if err := json.Unmarshal([]byte(valFromConfig), &rawElems); err != nil {
+		return nil, fmt.Errorf("expected JSON array of objects: %w", err)
+	}
+	if err := checkElemCount(params, len(rawElems)); err != nil {
+		return nil, err
+	}
+	elems := make([]map[string]string, len(rawElems))
+	for i, rawElem := range rawElems {
+		var rawParams map[string]json.RawMessage
+		if err := json.Unmarshal(rawElem, &rawParams); err != nil {
+			return nil, fmt.Errorf("slice element %d: expected JSON object, got %s", i, params.displayValue("%s", rawElem))
+		}
+		if rawParams == nil {
+			continue
+		}
+		elems[i] = map[string]string{}
+		for param, rawVal := range rawParams {
+			var str string
+			switch {
+			case string(rawVal) == "null":
+				continue
+			case json.Unmarshal(rawVal, &str) == nil:
+				elems[i][param] = str
+			default:
+				elems[i][param] = string(rawVal)
+			}
+		}
+	}
+	return elems, nil
+}
+
+// setStructSlice is a function that parses the elements of the struct slice, each element is parsed only
+// from its own parameters, the errors of all the elements are joined with their indices
+func (sc *ScalerConfig) setStructSlice(ctx context.Context, field reflect.Value, params Params, elems []map[string]string) error {
+	elemType := field.Type().Elem()
+	isPointer := elemType.Kind() == reflect.Pointer
+	slice := reflect.MakeSlice(field.Type(), len(elems), len(elems))
+	errs := []error{}
+	for i, elemParams := range elems {
+		if elemParams == nil {
+			if !isPointer {
+				errs = append(errs, fmt.Errorf("%s element %d is missing", params.FieldDisplayName(), i))
+			}
+			continue
+		}
+		elem := slice.Index(i)
+		if isPointer {
+			elem.Set(reflect.New(elemType.Elem()))
+			elem = elem.Elem()
+		}
+		elemConfig := *sc
+		elemConfig.TriggerMetadata = elemParams
+		elemConfig.ParsingOrderOverride = []ParsingOrder{TriggerMetadata}
+		elemConfig.ForceParsingOrderOverride = true
+		elemConfig.ReverseParsingOrder = false
+		elemConfig.report = nil
+		elemConfig.keyPrefix = ""
+		if err := elemConfig.parseTypedConfigValue(ctx, elem, false); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			errs = append(errs, fmt.Errorf("%s element %d: %w", params.FieldDisplayName(), i, err))
+		}
+	}
+	field.Set(slice)
+	return errors.Join(errs...)
+}
+
+// resolveRenamed is a function that falls back to the value of the previous name of the renamed parameter,
+// the deprecation warning is only logged when the previous name is used or its parsed value differs
+func (sc *ScalerConfig) resolveRenamed(ctx context.Context, field reflect.Value, params Params, valFromConfig string, exists bool) (string, bool, error) {
+	renamedParams := params
+	renamedParams.Name, renamedParams.AltNames, renamedParams.KeyPrefix = params.RenamedFrom, nil, ""
+	renamedVal, renamedExists := sc.configParamValue(renamedParams)
+	if !renamedExists {
+		return valFromConfig, exists, nil
+	}
+	if !exists {
+		sc.Logger.Info("deprecated parameter used", "parameter", params.RenamedFrom, "replacement", params.DisplayName())
+		return sc.interceptedParamValue(ctx, renamedParams)
+	}
+	if !parsedValuesEqual(ctx, params, field.Type(), valFromConfig, renamedVal) {
+		sc.Logger.Info("deprecated parameter ignored, conflicts with its replacement", "parameter", params.RenamedFrom, "replacement", params.DisplayName())
+	}
+	return valFromConfig, exists, nil
+}
+
+// parsedValuesEqual is a function that returns true if both values parse into equal values of the type,
+// e.g. 5 and 05 for integers, values that fail to parse are only equal if they are the same
+func parsedValuesEqual(ctx context.Context, params Params, t reflect.Type, a, b string) bool {
+	if a == b {
+		return true
+	}
+	aVal, bVal := reflect.New(t).Elem(), reflect.New(t).Elem()
+	if setConfigValueHelper(ctx, params, a, aVal) != nil || setConfigValueHelper(ctx, params, b, bVal) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aVal.Interface(), bVal.Interface())
+}
+
+// checkAllowedHosts is a function that verifies the host of the URL or url.URL field matches the 'allowHosts' tag
+// and the ScalerConfig.AllowedHosts, empty lists don't restrict the host
+func (sc *ScalerConfig) checkAllowedHosts(params Params, field reflect.Value) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	var u *url.URL
+	switch {
+	case field.Type() == urlType:
+		u = field.Addr().Interface().(*url.URL)
+	case field.Kind() == reflect.String:
+		parsed, err := url.Parse(field.String())
+		if err != nil || parsed.Hostname() == "" {
+			return fmt.Errorf("expected URL with host, got %s", params.displayValue("%q", field.String()))
+		}
+		u = parsed
+	default:
+		return fmt.Errorf("uses '%s' tag, expected string or url.URL field, has type %v", allowHostsTag, field.Type())
+	}
+	for _, allowed := range [][]string{params.AllowHosts, sc.AllowedHosts} {
+		if len(allowed) > 0 && !slices.ContainsFunc(allowed, func(pattern string) bool { return matchHost(pattern, u.Hostname()) }) {
+			return fmt.Errorf("host %q is not allowed, has to match one of %v", u.Hostname(), allowed)
+		}
+	}
+	return nil
+}
+
+// matchHost is a function that returns true if the host matches the pattern case insensitively,
+// the *. prefix of the pattern matches any subdomain but not the domain itself and * matches any host
+func matchHost(pattern, host string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if suffix, found := strings.CutPrefix(pattern, "*"); found {
+		return len(host) > len(suffix) && strings.HasSuffix(strings.ToLower(host), strings.ToLower(suffix))
+	}
+	return strings.EqualFold(pattern, host)
+}
+
+// numericValue is a function that returns the value of the integer or float field as float64
+func numericValue(field reflect.Value) (float64, bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(field.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(field.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// checkRange is a function that verifies the numeric field is within the 'min' and 'max' bounds
+func checkRange(params Params, field reflect.Value) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	val, ok := numericValue(field)
+	if !ok {
+		return fmt.Errorf("uses '%s' or '%s' tag, expected numeric field, has kind %q", minTag, maxTag, field.Kind())
+	}
+	if params.Min != nil && val < *params.Min {
+		return fmt.Errorf("value %s is less than the minimum %v", params.displayValue("%v", field), *params.Min)
+	}
+	if params.Max != nil && val > *params.Max {
+		return fmt.Errorf("value %s is greater than the maximum %v", params.displayValue("%v", field), *params.Max)
+	}
+	return nil
+}
+
+// clampValue is a function that clamps the numeric field into the 'clamp' bounds, integer fields are clamped
+// to the nearest integer within the bounds and the warning with the original value is logged
+func (sc *ScalerConfig) clampValue(params Params, field reflect.Value) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	val, ok := numericValue(field)
+	if !ok {
+		return fmt.Errorf("uses '%s' tag, expected numeric field, has kind %q", clampTag, field.Kind())
+	}
+	clamped := math.Max(params.Clamp[0], math.Min(params.Clamp[1], val))
+	if clamped == val {
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		field.SetFloat(clamped)
+	default:
+		if clamped < val {
+			clamped = math.Floor(clamped)
+		} else {
+			clamped = math.Ceil(clamped)
+		}
+		if (field.CanInt() && field.OverflowInt(int64(clamped))) || (field.CanUint() && (clamped < 0 || field.OverflowUint(uint64(clamped)))) {
+			return fmt.Errorf("clamped value %s overflows field type %v", params.displayValue("%v", clamped), field.Type())
+		}
+		if field.CanInt() {
+			field.SetInt(int64(clamped))
+		} else {
+			field.SetUint(uint64(clamped))
+		}
+	}
+	var loggedValue any = val
+	if params.Sensitive {
+		loggedValue = sensitiveValuePlaceholder
+	}
+	sc.Logger.Info("parameter value out of range, clamped", "parameter", params.DisplayName(), "value", loggedValue, "min", params.Clamp[0], "max", params.Clamp[1], "clamped", clamped)
+	return nil
+}
+
+// parseJSONPath is a function that splits the JSON path into the object keys and the array indexes,
+// e.g. $.brokers[0].port is ["brokers", 0, "port"]
+func parseJSONPath(path string) ([]any, error) {
+	rest, found := strings.CutPrefix(path, "$")
+	if !found {
+		return nil, fmt.Errorf("invalid jsonPath value %q, has to start with $", path)
+	}
+	segments := []any{}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid jsonPath value %q, empty key", path)
+			}
+			segments = append(segments, rest[1:end+1])
+			rest = rest[end+1:]
+		case '[':
+			index, remaining, found := strings.Cut(rest[1:], "]")
+			i, err := strconv.Atoi(index)
+			if !found || err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid jsonPath value %q, expected non-negative array index, got %q", path, index)
+			}
+			segments = append(segments, i)
+			rest = remaining
+		default:
+			return nil, fmt.Errorf("invalid jsonPath value %q, expected . or [ at %q", path, rest)
+		}
+	}
+	return segments, nil
+}
+
+// extractJSONPath is a function that returns the value at the JSON path, strings are returned as they are
+// and the other values as JSON, e.g. objects for map fields
+func extractJSONPath(path string, valFromConfig string) (string, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return "", err
+	}
+	decoder := json.NewDecoder(strings.NewReader(valFromConfig))
+	decoder.UseNumber()
+	var val any
+	if err := decoder.Decode(&val); err != nil {
+		return "", fmt.Errorf("expected JSON value for json path %s: %w", path, err)
+	}
+	for i, segment := range segments {
+		found := false
+		switch s := segment.(type) {
+		case string:
+			if obj, ok := val.(map[string]any); ok {
+				val, found = obj[s]
+			}
+		case int:
+			if arr, ok := val.([]any); ok && s < len(arr) {
+				val, found = arr[s], true
+			}
+		}
+		if !found || val == nil {
+			return "", fmt.Errorf("json path %s not found, %s is missing", path, jsonPathString(segments[:i+1]))
+		}
+	}
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	default:
+		out, err := json.Marshal(v)
+		return string(out), err
+	}
+}
+
+// jsonPathString is a function that formats the JSON path segments, e.g. $.brokers[0]
+func jsonPathString(segments []any) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, segment := range segments {
+		if i, ok := segment.(int); ok {
+			fmt.Fprintf(&sb, "[%d]", i)
+			continue
+		}
+		fmt.Fprintf(&sb, ".%s", segment)
+	}
+	return sb.String()
+}
+
+// checkEnum is a function that verifies the value is one of the 'enum' values, the value is split into
+// the elements for slice and set fields, with the 'ignoreCase' tag the value is returned in the 'enum' spelling
+func checkEnum(params Params, valFromConfig string, t reflect.Type) (string, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	values := []string{valFromConfig}
+	separator, _ := params.separators()
+	isList := (t.Kind() == reflect.Slice && !isMatrixType(t)) || (t.Kind() == reflect.Map && t.Elem() == emptyStructType)
+	if isList {
+		values = values[:0]
+		for _, val := range splitElems(valFromConfig, separator, "") {
+			val, err := unquoteElem(val)
+			if err != nil {
+				return valFromConfig, err
+			}
+			values = append(values, val)
+		}
+	}
+	for i, val := range values {
+		idx := slices.Index(params.Enum, val)
+		if idx < 0 && params.IgnoreCase {
+			idx = slices.IndexFunc(params.Enum, func(e string) bool { return strings.EqualFold(e, val) })
+		}
+		if idx < 0 {
+			return valFromConfig, fmt.Errorf("invalid value %s, allowed: %v", params.displayValue("%q", val), params.Enum)
+		}
+		values[i] = params.Enum[idx]
+	}
+	if !params.IgnoreCase {
+		return valFromConfig, nil
+	}
+	if isList {
+		return strings.Join(values, separator), nil
+	}
+	return values[0], nil
+}
+
+// checkUnique is a function that rejects slices with duplicate elements, the pointer elements are compared
+// by the values they point to
+func checkUnique(params Params, field reflect.Value) error {
+	for field.Kind() == reflect.Pointer && !field.IsNil() {
+		field = field.Elem()
+	}
+	if field.Kind() != reflect.Slice {
+		return fmt.Errorf("uses '%s' tag, expected slice field, has kind %q", uniqueTag, field.Kind())
+	}
+	seen := map[any]int{}
+	for i := 0; i < field.Len(); i++ {
+		elem := field.Index(i)
+		for elem.Kind() == reflect.Pointer && !elem.IsNil() {
+			elem = elem.Elem()
+		}
+		if elem.Comparable() {
+			if first, exists := seen[elem.Interface()]; exists {
+				return fmt.Errorf("duplicate slice element %s at index %d, first at index %d", params.displayValue("%v", elem), i, first)
+			}
+			seen[elem.Interface()] = i
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if reflect.DeepEqual(reflect.Indirect(field.Index(j)).Interface(), elem.Interface()) {
+				return fmt.Errorf("duplicate slice element %s at index %d, first at index %d", params.displayValue("%v", elem), i, j)
+			}
+		}
+	}
+	return nil
+}
+
+// checkRelatedFields is a function that verifies the 'exclusiveSet' and 'requiredIf' conditions of the field
+// on the sibling fields, the fields which failed to parse are skipped, the parsed params provide the display names
+func checkRelatedFields(parent reflect.Value, field reflect.Value, params Params, parsed map[string]Params, failed map[string]bool) error {
+	siblingDisplayName := func(name string) string {
+		if sibling, ok := parsed[name]; ok {
+			return sibling.FieldDisplayName()
+		}
+		return fmt.Sprintf("field %s", name)
+	}
+	for _, name := range params.ExclusiveSet {
+		other := parent.FieldByName(name)
+		if !other.IsValid() {
+			return fmt.Errorf("%s uses '%s' tag with unknown field %q", params.FieldDisplayName(), exclusiveSetTag, name)
+		}
+		if !failed[name] && !field.IsZero() && !other.IsZero() {
+			return fmt.Errorf("%s can't be set together with %s", params.FieldDisplayName(), siblingDisplayName(name))
+		}
+	}
+	for _, condition := range params.RequiredIf {
+		other := parent.FieldByName(condition.Field)
+		if !other.IsValid() {
+			return fmt.Errorf("%s uses '%s' tag with unknown field %q", params.FieldDisplayName(), requiredIfTag, condition.Field)
+		}
+		if failed[condition.Field] || !field.IsZero() {
+			continue
+		}
+		for other.Kind() == reflect.Pointer && !other.IsNil() {
+			other = other.Elem()
+		}
+		holds := !other.IsZero()
+		if condition.Value != "" {
+			holds = holds && fmt.Sprint(other.Interface()) == condition.Value
+		}
+		if holds {
+			return fmt.Errorf("missing required %s, required when %s %s", params.FieldDisplayName(), siblingDisplayName(condition.Field), condition)
+		}
+	}
+	return nil
+}
+
+// checkMultipleOf is a function that rejects integer values which are not a multiple of the 'multipleOf' step
+func checkMultipleOf(params Params, field reflect.Value) error {
+	for field.Kind() == reflect.Pointer && !field.IsNil() {
+		field = field.Elem()
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Int()%params.MultipleOf != 0 {
+			return fmt.Errorf("value %s is not a multiple of %d", params.displayValue("%d", field.Int()), params.MultipleOf)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if field.Uint()%uint64(params.MultipleOf) != 0 {
+			return fmt.Errorf("value %s is not a multiple of %d", params.displayValue("%d", field.Uint()), params.MultipleOf)
+		}
+	default:
+		return fmt.Errorf("uses '%s' tag, expected integer field, has kind %q", multipleOfTag, field.Kind())
+	}
+	return nil
+}
+
+// compareFields is a function that compares the numeric field with the sibling field from the comparison,
+// both fields have to be of the same type and the comparison is skipped if any of them is a nil pointer
+func compareFields(parent reflect.Value, field reflect.Value, params Params, comparison FieldComparison) error {
+	other := parent.FieldByName(comparison.Field)
+	if !other.IsValid() {
+		return fmt.Errorf("%s uses '%s' tag with unknown field %q", params.FieldDisplayName(), comparison.Tag, comparison.Field)
+	}
+	if other.Type() != field.Type() {
+		return fmt.Errorf("%s uses '%s' tag, field %s has type %v, expected %v", params.FieldDisplayName(), comparison.Tag, comparison.Field, other.Type(), field.Type())
+	}
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() || other.IsNil() {
+			return nil
+		}
+		field, other = field.Elem(), other.Elem()
+	}
+	var result int
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result = cmp.Compare(field.Int(), other.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result = cmp.Compare(field.Uint(), other.Uint())
+	case reflect.Float32, reflect.Float64:
+		result = cmp.Compare(field.Float(), other.Float())
+	default:
+		return fmt.Errorf("%s uses '%s' tag, expected numeric field, has kind %q", params.FieldDisplayName(), comparison.Tag, field.Kind())
+	}
+	if !fieldComparisons[comparison.Tag].check(result) {
+		return fmt.Errorf("%s value %s has to be %s field %s value %v", params.FieldDisplayName(), params.displayValue("%v", field.Interface()), fieldComparisons[comparison.Tag].desc, comparison.Field, other.Interface())
+	}
+	return nil
+}
+
+// expandTemplate is a function that executes the parsed string value of the field as text/template
+// with the parent struct as the data, fields are expanded in the order they are declared
+func expandTemplate(parent reflect.Value, field reflect.Value, params Params) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("%s uses '%s' tag, expected string field, has kind %q", params.FieldDisplayName(), templateTag, field.Kind())
+	}
+	tmpl, err := template.New(params.FieldName).Option("missingkey=error").Parse(field.String())
+	if err != nil {
+		return fmt.Errorf("%s template: %w", params.FieldDisplayName(), err)
+	}
+	data := parent.Interface()
+	if parent.CanAddr() {
+		data = parent.Addr().Interface()
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return fmt.Errorf("%s template: %w", params.FieldDisplayName(), err)
+	}
+	field.SetString(sb.String())
+	return nil
+}
+
+// defaultPlaceholderRegex matches the ${name} placeholders in the 'default' tag values
+var defaultPlaceholderRegex = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// hasNameVariables is a function that returns true if any of the parameter names contains a ${variable}
+func (p Params) hasNameVariables() bool {
+	return slices.ContainsFunc(append([]string{p.Name}, p.AltNames...), defaultPlaceholderRegex.MatchString)
+}
+
+// expandNameVariables is a function that replaces the ${variable} placeholders in the parameter names with the value
+// of the sibling field with the variable as its parameter name or with the ScalerConfig.NameVariables value,
+// e.g. name=queue_${env}_length, false is returned without an error if the referenced field failed to parse
+func (sc *ScalerConfig) expandNameVariables(parent reflect.Value, params Params, paramFields map[string]int, failed map[string]bool) (Params, bool, error) {
+	var errs []error
+	skip := false
+	expand := func(name string) string {
+		return defaultPlaceholderRegex.ReplaceAllStringFunc(name, func(placeholder string) string {
+			variable := defaultPlaceholderRegex.FindStringSubmatch(placeholder)[1]
+			if i, ok := paramFields[variable]; ok {
+				field := parent.Field(i)
+				if failed[parent.Type().Field(i).Name] {
+					skip = true
+					return placeholder
+				}
+				if field.Kind() == reflect.Pointer && !field.IsNil() {
+					field = field.Elem()
+				}
+				if field.Kind() == reflect.Pointer || field.IsZero() {
+					errs = append(errs, fmt.Errorf("field %s uses unresolved variable %s in name, the value of field %s is not set", params.FieldName, placeholder, parent.Type().Field(i).Name))
+					return placeholder
+				}
+				return fmt.Sprint(field.Interface())
+			}
+			if val, ok := sc.NameVariables[variable]; ok {
+				return val
+			}
+			errs = append(errs, fmt.Errorf("field %s uses unknown variable %s in name, has to be a parameter name of another field or a key of the name variables", params.FieldName, placeholder))
+			return placeholder
+		})
+	}
+	params.Name = expand(params.Name)
+	altNames := make([]string, len(params.AltNames))
+	for i, name := range params.AltNames {
+		altNames[i] = expand(name)
+	}
+	params.AltNames = altNames
+	if skip {
+		return params, false, nil
+	}
+	err := errors.Join(errs...)
+	return params, err == nil, err
+}
+
+// expandDefault is a function that replaces the placeholders in the default value with the values
+// identifying the scaler, i.e. ${namespace}, ${name} and ${triggerName}
+// placeholders that can't be resolved are an error unless ScalerConfig.AllowUnresolvedDefaults is set
+func (sc *ScalerConfig) expandDefault(defaultValue string) (string, error) {
+	var errs []error
+	expanded := defaultPlaceholderRegex.ReplaceAllStringFunc(defaultValue, func(placeholder string) string {
+		var val string
+		switch name := defaultPlaceholderRegex.FindStringSubmatch(placeholder)[1]; name {
+		case "namespace":
+			val = sc.ScalableObjectNamespace
+		case "name":
+			val = sc.ScalableObjectName
+		case "triggerName":
+			val = sc.TriggerName
+		default:
+			errs = append(errs, fmt.Errorf("unknown placeholder %s, has to be one of [${name} ${namespace} ${triggerName}]", placeholder))
+			return placeholder
+		}
+		if val == "" {
+			if !sc.AllowUnresolvedDefaults {
+				errs = append(errs, fmt.Errorf("unable to resolve placeholder %s, the value is not set", placeholder))
+			}
+			return placeholder
+		}
+		return val
+	})
+	return expanded, errors.Join(errs...)
+}
+
+// checkRequiredKeys is a function that verifies the parsed map field contains all the keys from the 'requiredKeys' tag
+func checkRequiredKeys(ctx context.Context, params Params, field reflect.Value) error {
+	if field.Kind() != reflect.Map {
+		return fmt.Errorf("uses 'requiredKeys' tag, expected map field, has kind %q", field.Kind())
+	}
+	missing := []string{}
+	for _, key := range params.RequiredKeys {
+		keyElem := reflect.New(field.Type().Key()).Elem()
+		if err := setConfigValueHelper(ctx, params, key, keyElem); err != nil {
+			return fmt.Errorf("required key %q: %w", key, err)
+		}
+		if !field.MapIndex(keyElem).IsValid() {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("is missing required keys %v", missing)
+	}
+	return nil
+}
+
+// setConfigValueURL is a function that sets the value of the url.URL field, the URL has to be absolute
+// with a host and one of the 'schemes' if the tag parameter is provided
+func setConfigValueURL(params Params, valFromConfig string, field reflect.Value) error {
+	u, err := url.Parse(valFromConfig)
+	if err != nil {
+		return fmt.Errorf("expected URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("expected absolute URL with scheme and host")
+	}
+	if len(params.Schemes) > 0 && !slices.ContainsFunc(params.Schemes, func(scheme string) bool { return strings.EqualFold(scheme, u.Scheme) }) {
+		return fmt.Errorf("unsupported URL scheme %q, has to be one of %v", u.Scheme, params.Schemes)
+	}
+	field.Set(reflect.ValueOf(*u))
+	return nil
+}
+
+// setConfigValueURLParams is a function that sets the value of the url.Values field
+func setConfigValueURLParams(ctx context.Context, params Params, valFromConfig string, field reflect.Value) error {
+	if err := checkElemCount(params, strings.Count(valFromConfig, "&")+1); err != nil {
+		return err
+	}
+	field.Set(reflect.MakeMap(reflect.MapOf(field.Type().Key(), field.Type().Elem())))
+	vals, err := url.ParseQuery(valFromConfig)
+	if err != nil {
+		return fmt.Errorf("expected url.Values, unable to parse query %q: %w", valFromConfig, err)
+	}
+	for k, vs := range vals {
+		ifcMapKeyElem := reflect.New(field.Type().Key()).Elem()
+		ifcMapValueElem := reflect.New(field.Type().Elem()).Elem()
+		if err := setConfigValueHelper(ctx, params, k, ifcMapKeyElem); err != nil {
+			return fmt.Errorf("map key %q: %w", k, err)
+		}
+		for _, v := range vs {
+			ifcMapValueElem.Set(reflect.Append(ifcMapValueElem, reflect.ValueOf(v)))
+		}
+		field.SetMapIndex(ifcMapKeyElem, ifcMapValueElem)
+	}
+	return nil
+}
+
+// setConfigValueMap is a function that sets the value of the map field
+// when a key occurs more than once, the last value wins unless the 'onDuplicate' tag parameter says otherwise
+func setConfigValueMap(ctx context.Context, params Params, valFromConfig string, field reflect.Value) error {
+	if strings.HasPrefix(valFromConfig, "{") && strings.HasSuffix(valFromConfig, "}") {
+		return setConfigValueMapJSON(params, valFromConfig, field)
+	}
+	separator, nestedSeparator := params.separators()
+	split := splitElems(valFromConfig, separator, params.keyValSeparator())
+	if err := checkElemCount(params, len(split)); err != nil {
+		return err
+	}
+	field.Set(reflect.MakeMap(reflect.MapOf(field.Type().Key(), field.Type().Elem())))
+	for _, s := range split {
+		kv := splitElems(s, params.keyValSeparator(), "")
+		if len(kv) != 2 {
+			return fmt.Errorf("expected format key%vvalue, got %s", params.keyValSeparator(), params.displayValue("%q", s))
+		}
+		key, err := unquoteElem(kv[0])
+		if err != nil {
+			return fmt.Errorf("map key %q: %w", kv[0], err)
+		}
+		val := kv[1]
+		// the slice values are unquoted element by element
+		if !isNestedSliceType(field.Type().Elem()) {
+			if val, err = unquoteElem(val); err != nil {
+				return fmt.Errorf("map key %q, value %s: %w", key, params.displayValue("%q", kv[1]), err)
+			}
+		}
+		ifcKeyElem := reflect.New(field.Type().Key()).Elem()
+		// the value parsing tags apply only to the map values
+		keyParams := params
+		keyParams.Rate, keyParams.Quantity = false, false
+		if err := setConfigValueHelper(ctx, keyParams, key, ifcKeyElem); err != nil {
+			return fmt.Errorf("map key %q: %w", key, err)
+		}
+		if field.MapIndex(ifcKeyElem).IsValid() {
+			switch params.OnDuplicate {
+			case OnDuplicateError:
+				return fmt.Errorf("duplicate map key %q", key)
+			case OnDuplicateFirst:
+				continue
+			}
+		}
+		ifcValueElem := reflect.New(field.Type().Elem()).Elem()
+		setElem := setConfigValueHelper
+		if isNestedSliceType(field.Type().Elem()) {
+			setElem = func(ctx context.Context, params Params, val string, field reflect.Value) error {
+				return setConfigValueSeparatedSlice(ctx, params, val, field, nestedSeparator)
+			}
+		}
+		if err := setElem(ctx, params, val, ifcValueElem); err != nil {
+			return fmt.Errorf("map key %q, value %s: %w", key, params.displayValue("%q", val), err)
+		}
+		field.SetMapIndex(ifcKeyElem, ifcValueElem)
+	}
+	return nil
+}
+
+// splitElems is a function that splits the value by the separator and trims the elements, the separators within
+// the double quoted elements are kept, e.g. "a,b",c is split into "a,b" and c, the quote is only recognized at
+// the start of the element or right after the keyValSeparator, so the values not starting with a quote are split
+// as they are, within the quotes the backslash escapes the next character, e.g. "say \"hi\"", and a quote
+// without the closing one is kept as a plain character
+func splitElems(val, separator, keyValSeparator string) []string {
+	elems := []string{}
+	start := 0
+	for i := 0; i < len(val); {
+		if val[i] == '"' && quoteAllowed(val[start:i], keyValSeparator) {
+			if end, found := closingQuote(val, i); found {
+				i = end + 1
+				continue
+			}
+		}
+		if strings.HasPrefix(val[i:], separator) {
+			elems = append(elems, strings.TrimSpace(val[start:i]))
+			i += len(separator)
+			start = i
+			continue
+		}
+		i++
+	}
+	return append(elems, strings.TrimSpace(val[start:]))
+}
+
+// quoteAllowed is a function that returns true if the quote can open after the preceding part of the element,
+// i.e. the part is empty or ends with the keyValSeparator
+func quoteAllowed(preceding, keyValSeparator string) bool {
+	preceding = strings.TrimSpace(preceding)
+	return preceding == "" || (keyValSeparator != "" && strings.HasSuffix(preceding, keyValSeparator))
+}
+
+// closingQuote is a function that returns the index of the quote closing the quote at the start index,
+// the quotes escaped with the backslash are skipped
+func closingQuote(val string, start int) (int, bool) {
+	for i := start + 1; i < len(val); i++ {
+		switch val[i] {
+		case '\\':
+			i++
+		case '"':
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// unquoteElem is a function that removes the quotes of the double quoted element and resolves the Go escape
+// sequences within, e.g. \" or \n, the elements not enclosed in quotes are returned as they are
+func unquoteElem(elem string) (string, error) {
+	if len(elem) < 2 || !strings.HasPrefix(elem, `"`) || !strings.HasSuffix(elem, `"`) {
+		return elem, nil
+	}
+	unquoted, err := strconv.Unquote(elem)
+	if err != nil {
+		return "", fmt.Errorf("expected quoted value with valid escape sequences")
+	}
+	return unquoted, nil
+}
+
+// setConfigValueSlice is a function that sets the value of the slice field
+func setConfigValueSlice(ctx context.Context, params Params, valFromConfig string, field reflect.Value) error {
+	separator, _ := params.separators()
+	return setConfigValueSeparatedSlice(ctx, params, valFromConfig, field, separator)
+}
+
+// setConfigValueSeparatedSlice is a function that sets the value of the slice field with elements split by the separator
+func setConfigValueSeparatedSlice(ctx context.Context, params Params, valFromConfig string, field reflect.Value, separator string) error {
+	split := splitElems(valFromConfig, separator, "")
+	if err := checkElemCount(params, len(split)); err != nil {
+		return err
+	}
+	elemIfc := reflect.New(field.Type().Elem()).Interface()
+	field.Set(reflect.MakeSlice(field.Type(), 0, len(split)))
+	for i, s := range split {
+		s, err := unquoteElem(s)
+		if err != nil {
+			return fmt.Errorf("slice element %d: %w", i, err)
+		}
+		if err := setConfigValueHelper(ctx, params, s, reflect.ValueOf(elemIfc).Elem()); err != nil {
+			return fmt.Errorf("slice element %d: %w", i, err)
+		}
+		field.Set(reflect.Append(field, reflect.ValueOf(elemIfc).Elem()))
+	}
+	return nil
+}
+
+// setConfigValueCSV is a function that parses the value as a single encoding/csv record into the slice elements,
+// the leading spaces of the fields are trimmed the same as with the plain slices
+func setConfigValueCSV(ctx context.Context, params Params, valFromConfig string, field reflect.Value) error {
+	if field.Kind() != reflect.Slice {
+		return fmt.Errorf("uses '%s' tag, expected slice field, has kind %q", csvTag, field.Kind())
+	}
+	reader := csv.NewReader(strings.NewReader(valFromConfig))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return fmt.Errorf("expected CSV record: %w", err)
+	}
+	if len(records) != 1 {
+		return fmt.Errorf("expected a single CSV record, got %d", len(records))
+	}
+	if err := checkElemCount(params, len(records[0])); err != nil {
+		return err
+	}
+	elemParams := params
+	elemParams.CSV = false
+	slice := reflect.MakeSlice(field.Type(), len(records[0]), len(records[0]))
+	for i, s := range records[0] {
+		if err := setConfigValueHelper(ctx, elemParams, s, slice.Index(i)); err != nil {
+			return fmt.Errorf("slice element %d: %w", i, err)
+		}
+	}
+	field.Set(slice)
+	return nil
+}
+
+// setConfigValueSet is a function that sets the value of the map[K]struct{} set field from the list of members,
+// e.g. a,b,a is the set of a and b, duplicate members collapse unless the 'onDuplicate' tag parameter says otherwise
+func setConfigValueSet(ctx context.Context, params Params, valFromConfig string, field reflect.Value) error {
+	separator, _ := params.separators()
+	split := splitElems(valFromConfig, separator, "")
+	if err := checkElemCount(params, len(split)); err != nil {
+		return err
+	}
+	field.Set(reflect.MakeMap(field.Type()))
+	for i, s := range split {
+		s, err := unquoteElem(s)
+		if err != nil {
+			return fmt.Errorf("set member %d: %w", i, err)
+		}
+		member := reflect.New(field.Type().Key()).Elem()
+		if err := setConfigValueHelper(ctx, params, s, member); err != nil {
+			return fmt.Errorf("set member %s: %w", params.displayValue("%q", s), err)
+		}
+		if field.MapIndex(member).IsValid() && params.OnDuplicate == OnDuplicateError {
+			return fmt.Errorf("duplicate set member %s", params.displayValue("%q", s))
+		}
+		field.SetMapIndex(member, reflect.ValueOf(struct{}{}))
+	}
+	return nil
+}
+
+// setConfigValueMapJSON is a function that sets the value of the map field from the JSON object,
+// e.g. {"a": [1, 2], "b": [3]} is the same as a=1;2,b=3
+func setConfigValueMapJSON(params Params, valFromConfig string, field reflect.Value) error {
+	m := reflect.New(field.Type())
+	if err := json.Unmarshal([]byte(valFromConfig), m.Interface()); err != nil {
+		return fmt.Errorf("expected JSON object: %w", err)
+	}
+	if err := checkElemCount(params, m.Elem().Len()); err != nil {
+		return err
+	}
+	field.Set(m.Elem())
+	return nil
+}
+
+// isNestedSliceType is a function that returns true for the slice values of maps parsed with the nested separator
+func isNestedSliceType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && !isMatrixType(t) && !isPairType(t.Elem()) && !isStructSliceType(t)
+}
+
+// setConfigValuePairs is a function that sets the value of the slice of key-value pairs field, unlike maps
+// the pairs keep the order from the config and the same key may occur more than once, e.g. a=1,b=2,a=3
+func setConfigValuePairs(ctx context.Context, params Params, valFromConfig string, field reflect.Value) error {
+	separator, _ := params.separators()
+	split := splitElems(valFromConfig, separator, params.keyValSeparator())
+	if err := checkElemCount(params, len(split)); err != nil {
+		return err
+	}
+	pairs := reflect.MakeSlice(field.Type(), 0, len(split))
+	for i, s := range split {
+		kv := splitElems(s, params.keyValSeparator(), "")
+		if len(kv) != 2 {
+			return fmt.Errorf("pair %d: expected format key%vvalue, got %s", i, params.keyValSeparator(), params.displayValue("%q", s))
+		}
+		key, err := unquoteElem(kv[0])
+		if err != nil {
+			return fmt.Errorf("pair %d, key %q: %w", i, kv[0], err)
+		}
+		val, err := unquoteElem(kv[1])
+		if err != nil {
+			return fmt.Errorf("pair %d, key %q, value %s: %w", i, key, params.displayValue("%q", kv[1]), err)
+		}
+		pair := reflect.New(field.Type().Elem()).Elem()
+		// the value parsing tags apply only to the pair values
+		keyParams := params
+		keyParams.Rate, keyParams.Quantity = false, false
+		if err := setConfigValueHelper(ctx, keyParams, key, pair.Field(0)); err != nil {
+			return fmt.Errorf("pair %d, key %q: %w", i, key, err)
+		}
+		if err := setConfigValueHelper(ctx, params, val, pair.Field(1)); err != nil {
+			return fmt.Errorf("pair %d, key %q, value %s: %w", i, key, params.displayValue("%q", val), err)
+		}
+		pairs = reflect.Append(pairs, pair)
+	}
+	field.Set(pairs)
+	return nil
+}
+
+// isPairType is a function that returns true for the key-value pair structs, i.e. structs with exactly
+// two exported fields, the first one is the key and the second one the value, e.g. struct{ Key, Value string },
+// structs with a registered codec are parsed by the codec instead
+func isPairType(t reflect.Type) bool {
+	if _, ok := lookupCodec(t); ok {
+		return false
+	}
+	return t.Kind() == reflect.Struct && t.NumField() == 2 && t.Field(0).IsExported() && t.Field(1).IsExported()
+}
+
+// setConfigValueMatrix is a function that sets the value of the two dimensional slice field,
+// rows are split by the 'rowSeparator' and the columns within a row by the 'columnSeparator'
+func setConfigValueMatrix(ctx context.Context, params Params, valFromConfig string, field reflect.Value) error {
+	rowSeparator, columnSeparator := defaultRowSeparator, defaultColumnSeparator
+	if params.RowSeparator != "" {
+		rowSeparator = params.RowSeparator
+	}
+	if params.ColumnSeparator != "" {
+		columnSeparator = params.ColumnSeparator
+	}
+	if err := checkElemCount(params, strings.Count(valFromConfig, rowSeparator)+1); err != nil {
+		return fmt.Errorf("rows: %w", err)
+	}
+	rowType := field.Type().Elem()
+	matrix := reflect.MakeSlice(field.Type(), 0, 0)
+	cells := 0
+	for i, r := range strings.Split(valFromConfig, rowSeparator) {
+		cells += strings.Count(strings.TrimSpace(r), columnSeparator) + 1
+		if err := checkElemCount(params, cells); err != nil {
+			return fmt.Errorf("cells: %w", err)
+		}
+		columns := strings.Split(strings.TrimSpace(r), columnSeparator)
+		if params.Rectangular && i > 0 && len(columns) != matrix.Index(0).Len() {
+			return fmt.Errorf("row %d has %d columns, expected %d", i, len(columns), matrix.Index(0).Len())
+		}
+		row := reflect.MakeSlice(rowType, 0, len(columns))
+		for j, c := range columns {
+			c := strings.TrimSpace(c)
+			elem := reflect.New(rowType.Elem()).Elem()
+			if err := setConfigValueHelper(ctx, params, c, elem); err != nil {
+				return fmt.Errorf("row %d, column %d: %w", i, j, err)
+			}
+			row = reflect.Append(row, elem)
+		}
+		matrix = reflect.Append(matrix, row)
+	}
+	field.Set(matrix)
+	return nil
+}
+
+// isMatrixType is a function that returns true for two dimensional slices, e.g. [][]string
+func isMatrixType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Slice && t.Elem() != reflect.TypeOf([]byte{})
+}
+
+// isCodecSliceType is a function that returns true for the slices with a registered codec of the elements,
+// which are parsed element by element even if the string is convertible to the slice, e.g. enums of bytes
+func isCodecSliceType(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	_, ok := lookupCodec(t.Elem())
+	return ok
+}
+
+// setConfigValueCodec is a function that sets the field to the value parsed by the registered codec,
+// nil sets the zero value
+func setConfigValueCodec(ctx context.Context, codec CodecContextFunc, valFromConfig string, field reflect.Value) error {
+	val, err := codec(ctx, valFromConfig)
+	if err != nil {
+		return err
+	}
+	if val == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if !reflect.TypeOf(val).AssignableTo(field.Type()) {
+		return fmt.Errorf("codec for type %v returned value of type %T", field.Type(), val)
+	}
+	field.Set(reflect.ValueOf(val))
+	return nil
+}
+
+// setConfigValueHelper is a function that sets the value of the parameter
+func setConfigValueHelper(ctx context.Context, params Params, valFromConfig string, field reflect.Value) error {
+	if isScalarKind(field.Kind()) {
+		// values from files or multiline yaml may carry a trailing newline or CRLF
+		valFromConfig = strings.TrimRight(valFromConfig, "\r\n")
+	}
+	if params.TrimCutset != "" && field.Kind() != reflect.Pointer {
+		valFromConfig = strings.Trim(valFromConfig, params.TrimCutset)
+	}
+	if codec, ok := lookupCodec(field.Type()); ok {
+		return setConfigValueCodec(ctx, codec, valFromConfig, field)
+	}
+	if params.Lazy {
+		return setConfigValueLazy(valFromConfig, field)
+	}
+	if params.TypeHints && field.Type() == anyType {
+		val, err := parseTypeHinted(valFromConfig)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(val))
+		return nil
+	}
+	// compiled patterns are pointers, so they have to be handled before the generic pointer branch
+	if field.Type() == regexpType {
+		re, err := regexp.Compile(valFromConfig)
+		if err != nil {
+			return fmt.Errorf("expected regular expression: %w", err)
+		}
+		field.Set(reflect.ValueOf(re))
+		return nil
+	}
+	if field.Kind() == reflect.Pointer {
+		elem := reflect.New(field.Type().Elem())
+		if err := setConfigValueHelper(ctx, params, valFromConfig, elem.Elem()); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if params.CSV {
+		return setConfigValueCSV(ctx, params, valFromConfig, field)
+	}
+	if params.Ratio && field.Kind() != reflect.Slice && field.Kind() != reflect.Map {
+		return setConfigValueRatio(ctx, valFromConfig, field)
+	}
+	if params.Percent != "" && isScalarKind(field.Kind()) {
+		return setConfigValuePercent(params, valFromConfig, field)
+	}
+	if params.Rate && isScalarKind(field.Kind()) {
+		return setConfigValueRate(valFromConfig, field)
+	}
+	if params.Quantity && isScalarKind(field.Kind()) {
+		return setConfigValueQuantity(valFromConfig, field)
+	}
+	if params.HumanInt && isScalarKind(field.Kind()) {
+		if !isIntegerKind(field.Kind()) {
+			return fmt.Errorf("uses '%s' tag, expected integer field, has kind %q", humanIntTag, field.Kind())
+		}
+		if !humanIntRegexp.MatchString(valFromConfig) {
+			return fmt.Errorf("expected integer with digits optionally grouped by single underscores")
+		}
+		valFromConfig = strings.ReplaceAll(valFromConfig, "_", "")
+	}
+	paramValue := reflect.ValueOf(valFromConfig)
+	if paramValue.Type().AssignableTo(field.Type()) {
+		// any fields are assignable from string too, so SetString would panic
+		field.Set(paramValue)
+		return nil
+	}
+	if paramValue.Type().ConvertibleTo(field.Type()) && !isCodecSliceType(field.Type()) {
+		field.Set(paramValue.Convert(field.Type()))
+		return nil
+	}
+	if field.Type() == weekdayType {
+		weekday, err := parseWeekday(valFromConfig)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(weekday))
+		return nil
+	}
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		duration, err := parseDuration(valFromConfig, params.DefaultUnit)
+		if err != nil {
+			return err
+		}
+		if params.DurationUnit > 0 {
+			duration = adjustDuration(duration, params.DurationUnit, params.DurationMode)
+		}
+		field.SetInt(int64(duration))
+		return nil
+	}
+	if field.Kind() == reflect.Bool && params.TrueToken != "" {
+		switch {
+		case strings.EqualFold(valFromConfig, params.TrueToken):
+			field.SetBool(true)
+		case strings.EqualFold(valFromConfig, params.FalseToken):
+			field.SetBool(false)
+		default:
+			return fmt.Errorf("expected %q or %q", params.TrueToken, params.FalseToken)
+		}
+		return nil
+	}
+	if field.Kind() == reflect.Bool {
+		boolVal, err := strconv.ParseBool(valFromConfig)
+		if err != nil {
+			return fmt.Errorf("expected bool value")
+		}
+		field.SetBool(boolVal)
+		return nil
+	}
+	if field.Type() == reflect.TypeOf(url.Values{}) {
+		return setConfigValueURLParams(ctx, params, valFromConfig, field)
+	}
+	if field.Type() == urlType {
+		return setConfigValueURL(params, valFromConfig, field)
+	}
+	if field.Type() == labelsSetType {
+		if err := setConfigValueMap(ctx, params, valFromConfig, field); err != nil {
+			return err
+		}
+		return validateLabelsSet(field.Interface().(labels.Set))
+	}
+	if field.Kind() == reflect.Map && field.Type().Elem() == emptyStructType {
+		return setConfigValueSet(ctx, params, valFromConfig, field)
+	}
+	if field.Kind() == reflect.Map {
+		return setConfigValueMap(ctx, params, valFromConfig, field)
+	}
+	if isMatrixType(field.Type()) {
+		return setConfigValueMatrix(ctx, params, valFromConfig, field)
+	}
+	if field.Kind() == reflect.Slice && isPairType(field.Type().Elem()) {
+		return setConfigValuePairs(ctx, params, valFromConfig, field)
+	}
+	if field.Kind() == reflect.Slice {
+		return setConfigValueSlice(ctx, params, valFromConfig, field)
+	}
+	if field.Type() == labelsSelectorType {
+		selector, err := labels.Parse(valFromConfig)
+		if err != nil {
+			return fmt.Errorf("expected label selector: %w", err)
+		}
+		field.Set(reflect.ValueOf(selector))
+		return nil
+	}
+	if field.CanAddr() && field.Addr().CanInterface() {
+		if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
+			if err := scanner.Scan(valFromConfig); err != nil {
+				return fmt.Errorf("unable to scan to field type %v: %w", field.Type(), err)
+			}
+			return nil
+		}
+	}
+	if isScalarKind(field.Kind()) && !reflect.PointerTo(field.Type()).Implements(jsonUnmarshalerType) {
+		return setConfigValueNumber(valFromConfig, field)
+	}
+	if field.CanInterface() {
+		ifc := reflect.New(field.Type()).Interface()
+		if err := json.Unmarshal([]byte(valFromConfig), &ifc); err != nil {
+			return fmt.Errorf("unable to unmarshal to field type %v: %w", field.Type(), err)
+		}
+		field.Set(reflect.ValueOf(ifc).Elem())
+		return nil
+	}
+	return fmt.Errorf("unable to find matching parser for field type %v", field.Type())
+}
+
+// anyType is the type of the fields parsed with the 'typeHints' tag
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// parseTypeHinted is a function that parses the value according to its type prefix, e.g. i:5 into int64(5)
+func parseTypeHinted(val string) (any, error) {
+	hint, raw, found := strings.Cut(val, ":")
+	if !found {
+		return nil, fmt.Errorf("expected type prefix i:, f:, b: or s:")
+	}
+	switch hint {
+	case "i":
+		i, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected int value after the type prefix")
+		}
+		return i, nil
+	case "f":
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected float value after the type prefix")
+		}
+		return f, nil
+	case "b":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected bool value after the type prefix")
+		}
+		return b, nil
+	case "s":
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("unknown type prefix %q, has to be one of i, f, b or s", hint)
+	}
+}
+
+// adjustDuration is a function that rounds or truncates the duration to a multiple of the unit
+func adjustDuration(duration, unit time.Duration, mode DurationMode) time.Duration {
+	if mode == DurationModeTruncate {
+		return duration.Truncate(unit)
+	}
+	return duration.Round(unit)
+}
+
+// weekdayType is the type of the weekday fields, parsed from the weekday names instead of the numeric values
+var weekdayType = reflect.TypeOf(time.Weekday(0))
+
+// parseDuration is a function that parses the duration, with non-zero defaultUnit a unit-less number
+// is accepted and multiplied by the unit, e.g. 30 with defaultUnit of time.Second is 30s
+func parseDuration(val string, defaultUnit time.Duration) (time.Duration, error) {
+	duration, err := time.ParseDuration(val)
+	if err == nil {
+		return duration, nil
+	}
+	if defaultUnit == 0 {
+		return 0, fmt.Errorf("expected duration value")
+	}
+	number, err := strconv.ParseFloat(val, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("expected duration value or number of %v", defaultUnit)
+	}
+	scaled := number * float64(defaultUnit)
+	if scaled < math.MinInt64 || scaled >= math.MaxInt64 {
+		return 0, fmt.Errorf("duration overflows")
+	}
+	return time.Duration(scaled), nil
+}
+
+// parseWeekday is a function that parses the case insensitive weekday name, either full or abbreviated
+// to the first three letters, e.g. Monday, mon or MON
+func parseWeekday(val string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(val, d.String()) || strings.EqualFold(val, d.String()[:3]) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("expected weekday name")
+}
+
+// urlType is the type of the URL fields, parsed with url.Parse instead of JSON
+var urlType = reflect.TypeOf(url.URL{})
+
+// regexpType is the type of the compiled regular expression fields
+var regexpType = reflect.TypeOf((*regexp.Regexp)(nil))
+
+// labelsSelectorType is the type of the label selector fields, it's an interface so it can't be unmarshalled from JSON
+var labelsSelectorType = reflect.TypeOf((*labels.Selector)(nil)).Elem()
+
+// jsonUnmarshalerType is the type of the fields with custom JSON parsing, they skip the strict numeric parsing
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// emptyStructType is the value type of the map[K]struct{} set fields
+var emptyStructType = reflect.TypeOf(struct{}{})
+
+// labelsSetType is the type of the label set fields, the keys and values are validated against the Kubernetes label rules
+var labelsSetType = reflect.TypeOf(labels.Set{})
+
+// types of the fields supported by the 'lazy' tag
+var (
+	lazyStringType          = reflect.TypeOf(func() string { return "" })
+	lazyStringWithErrorType = reflect.TypeOf(func() (string, error) { return "", nil })
+)
+
+// setConfigValueLazy is a function that sets the function field to a closure returning the value
+func setConfigValueLazy(valFromConfig string, field reflect.Value) error {
+	switch field.Type() {
+	case lazyStringType:
+		field.Set(reflect.ValueOf(func() string { return valFromConfig }))
+	case lazyStringWithErrorType:
+		field.Set(reflect.ValueOf(func() (string, error) { return valFromConfig, nil }))
+	default:
+		return fmt.Errorf("uses '%s' tag, expected %v or %v field, has type %v", lazyTag, lazyStringType, lazyStringWithErrorType, field.Type())
+	}
+	return nil
+}
+
+// byteSizeRegex matches the byte sizes, e.g. 10MB, 1.5 GiB or 512B
+var byteSizeRegex = regexp.MustCompile(`^([+-]?[0-9]+(?:\.[0-9]+)?)\s*([KMGTP]i?B|B)$`)
+
+// byteSizeUnits are the multipliers of the byte size units, KB, MB, ... are decimal and KiB, MiB, ... are binary
+var byteSizeUnits = map[string]float64{
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"PB":  1e15,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+	"PiB": 1 << 50,
+}
+
+// validateLabelsSet is a function that validates the keys and values of the label set against the Kubernetes
+// label rules, e.g. the keys are qualified names with an optional DNS subdomain prefix of at most 63 characters
+func validateLabelsSet(set labels.Set) error {
+	for _, key := range sortedKeys(set) {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, tagValueSeparator+" "))
+		}
+		if errs := validation.IsValidLabelValue(set[key]); len(errs) > 0 {
+			return fmt.Errorf("invalid label value %q of key %q: %s", set[key], key, strings.Join(errs, tagValueSeparator+" "))
+		}
+	}
+	return nil
+}
+
+// setConfigValueNumber is a function that strictly parses the integer and float values with strconv,
+// any leading or trailing characters are rejected, e.g. "5 replicas" or "5abc" is an error and not 5
+func setConfigValueNumber(valFromConfig string, field reflect.Value) error {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val, err := strconv.ParseInt(valFromConfig, 10, field.Type().Bits())
+		if errors.Is(err, strconv.ErrRange) {
+			return fmt.Errorf("overflows field type %v", field.Type())
+		}
+		if err != nil {
+			return fmt.Errorf("expected integer value")
+		}
+		field.SetInt(val)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val, err := strconv.ParseUint(valFromConfig, 10, field.Type().Bits())
+		if errors.Is(err, strconv.ErrRange) {
+			return fmt.Errorf("overflows field type %v", field.Type())
+		}
+		if err != nil {
+			return fmt.Errorf("expected unsigned integer value")
+		}
+		field.SetUint(val)
+	case reflect.Float32, reflect.Float64:
+		val, err := strconv.ParseFloat(valFromConfig, field.Type().Bits())
+		if errors.Is(err, strconv.ErrRange) {
+			return fmt.Errorf("overflows field type %v", field.Type())
+		}
+		if err != nil || math.IsNaN(val) || math.IsInf(val, 0) {
+			return fmt.Errorf("expected float value")
+		}
+		field.SetFloat(val)
+	default:
+		return fmt.Errorf("unable to find matching parser for field type %v", field.Type())
+	}
+	return nil
+}
+
+// setConfigValuePercent is a function that parses the signed percentage with the % suffix into the float field,
+// the sign is preserved, e.g. -25% is -0.25 in the fraction mode and -25 in the whole mode
+func setConfigValuePercent(params Params, valFromConfig string, field reflect.Value) error {
+	if field.Kind() != reflect.Float32 && field.Kind() != reflect.Float64 {
+		return fmt.Errorf("uses '%s' tag, expected float field, has kind %q", percentTag, field.Kind())
+	}
+	number, found := strings.CutSuffix(valFromConfig, "%")
+	if !found {
+		return fmt.Errorf("expected percentage with %% suffix, e.g. -25%%")
+	}
+	percent, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || math.IsNaN(percent) || math.IsInf(percent, 0) {
+		return fmt.Errorf("expected percentage with %% suffix, e.g. -25%%")
+	}
+	if params.Percent == PercentModeFraction {
+		percent /= 100
+	}
+	field.SetFloat(percent)
+	return nil
+}
+
+// ratioSeparator separates the two parts of the ratio, e.g. 3:2
+const ratioSeparator = ":"
+
+// setConfigValueRatio is a function that parses the a:b ratio into a/b for float fields or into the two elements
+// of a [2]T array or a struct with two exported fields, the denominator can't be zero
+func setConfigValueRatio(ctx context.Context, valFromConfig string, field reflect.Value) error {
+	a, b, found := strings.Cut(valFromConfig, ratioSeparator)
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if !found {
+		return fmt.Errorf("expected ratio in format a%sb", ratioSeparator)
+	}
+	numerator, err := strconv.ParseFloat(a, 64)
+	if err != nil {
+		return fmt.Errorf("expected number as ratio numerator, got %q", a)
+	}
+	denominator, err := strconv.ParseFloat(b, 64)
+	if err != nil {
+		return fmt.Errorf("expected number as ratio denominator, got %q", b)
+	}
+	if denominator == 0 {
+		return fmt.Errorf("ratio has zero denominator")
+	}
+	switch {
+	case field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64:
+		field.SetFloat(numerator / denominator)
+		return nil
+	case field.Kind() == reflect.Array && field.Len() == 2, isPairType(field.Type()):
+		for i, part := range []string{a, b} {
+			elem := field.Index
+			if field.Kind() == reflect.Struct {
+				elem = field.Field
+			}
+			if err := setConfigValueHelper(ctx, Params{}, part, elem(i)); err != nil {
+				return fmt.Errorf("ratio part %q: %w", part, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("uses '%s' tag, expected float, two element array or two field struct, has type %v", ratioTag, field.Type())
+	}
+}
+
+// parseByteSize is a function that parses the byte size with unit into the number of bytes
+func parseByteSize(val string) (float64, error) {
+	match := byteSizeRegex.FindStringSubmatch(val)
+	if match == nil {
+		return 0, fmt.Errorf("expected byte size with one of the units %v", sortedKeys(byteSizeUnits))
+	}
+	num, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("expected byte size: %w", err)
+	}
+	return num * byteSizeUnits[match[2]], nil
+}
+
+// setConfigValueRate is a function that sets the integer field to the bytes per second of the rate, e.g. 10MB/s
+func setConfigValueRate(valFromConfig string, field reflect.Value) error {
+	size, found := strings.CutSuffix(strings.TrimSpace(valFromConfig), "/s")
+	if !found {
+		return fmt.Errorf("expected rate with /s suffix, e.g. 10MB/s")
+	}
+	bytesPerSecond, err := parseByteSize(strings.TrimSpace(size))
+	if err != nil {
+		return err
+	}
+	if bytesPerSecond != math.Trunc(bytesPerSecond) {
+		return fmt.Errorf("rate is not a whole number of bytes per second")
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if bytesPerSecond < math.MinInt64 || bytesPerSecond >= math.MaxInt64 || field.OverflowInt(int64(bytesPerSecond)) {
+			return fmt.Errorf("rate overflows field type %v", field.Type())
+		}
+		field.SetInt(int64(bytesPerSecond))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if bytesPerSecond < 0 {
+			return fmt.Errorf("rate is negative, field type %v is unsigned", field.Type())
+		}
+		if bytesPerSecond >= math.MaxUint64 || field.OverflowUint(uint64(bytesPerSecond)) {
+			return fmt.Errorf("rate overflows field type %v", field.Type())
+		}
+		field.SetUint(uint64(bytesPerSecond))
+	default:
+		return fmt.Errorf("uses '%s' tag, expected integer field, has kind %q", rateTag, field.Kind())
+	}
+	return nil
+}
+
+// setConfigValueQuantity is a function that sets the integer field to the value of the Kubernetes quantity, e.g. 1Mi
+func setConfigValueQuantity(valFromConfig string, field reflect.Value) error {
+	quantity, err := resource.ParseQuantity(strings.TrimSpace(valFromConfig))
+	if err != nil {
+		return fmt.Errorf("expected quantity value")
+	}
+	value, ok := quantity.AsInt64()
+	if !ok {
+		return fmt.Errorf("quantity is not a whole number within the int64 range")
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.OverflowInt(value) {
+			return fmt.Errorf("quantity overflows field type %v", field.Type())
+		}
+		field.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value < 0 {
+			return fmt.Errorf("quantity is negative, field type %v is unsigned", field.Type())
+		}
+		if field.OverflowUint(uint64(value)) {
+			return fmt.Errorf("quantity overflows field type %v", field.Type())
+		}
+		field.SetUint(uint64(value))
+	default:
+		return fmt.Errorf("uses '%s' tag, expected integer field, has kind %q", quantityTag, field.Kind())
+	}
+	return nil
+}
+
+// humanIntRegexp matches integers with the digits optionally grouped by single underscores, e.g. 1_000_000
+var humanIntRegexp = regexp.MustCompile(`^[+-]?[0-9]+(_[0-9]+)*$`)
+
+// isIntegerKind is a function that returns true for the signed and unsigned integer kinds
+func isIntegerKind(kind reflect.Kind) bool {
+	return isScalarKind(kind) && kind != reflect.Bool && kind != reflect.Float32 && kind != reflect.Float64
+}
+
+// isScalarKind is a function that returns true for the bool and numeric kinds
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// configParamValue is a function that returns the value of the parameter based on the parsing order
+// the sources from the parsing order are the outer loop and the names are the inner loop, i.e. all names
+// are tried within the first source before moving on to the next source, a name listed later in a source
+// earlier in the parsing order takes precedence over the first name in a later source
+func (sc *ScalerConfig) configParamValue(params Params) (string, bool) {
+	_, val, exists := sc.lookupParamValue(params)
+	return val, exists
+}
+
+// lookupParamValue is a function that works like configParamValue but also returns the name of the parameter
+// the value was found under, i.e. one of the names or the key of the fallback step
+func (sc *ScalerConfig) lookupParamValue(params Params) (string, string, bool) {
+	if len(params.Fallback) > 0 {
+		for _, step := range params.Fallback {
+			m, ok := sc.sourceMap(step.Source)
+			if !ok {
+				return "", "", false
+			}
+			param, ok := m[step.Key]
+			if ok {
+				sc.report.consume(step.Source, step.Key)
+			}
+			if ok && (param != "" || params.AllowEmpty || sc.AllowEmptyValues) {
+				return step.Key, strings.TrimSpace(param), true
+			}
+		}
+		return "", "", false
+	}
+	for _, po := range params.Order {
+		m, ok := sc.sourceMap(po)
+		if !ok {
+			return "", "", false
+		}
+		for _, name := range params.Names() {
+			key := name
+			switch po {
+			case ResolvedEnv:
+				envKey := fmt.Sprintf("%sFromEnv", name)
+				key = sc.TriggerMetadata[envKey]
+				if key != "" {
+					sc.report.consume(TriggerMetadata, envKey)
+				}
+			case AuthParams:
+				if !params.FromAuth {
+					break
+				}
+				// <name>FromAuth in triggerMetadata redirects the lookup to another authParams key
+				authKeyName := fmt.Sprintf("%sFromAuth", name)
+				if authKey, ok := sc.TriggerMetadata[authKeyName]; ok && authKey != "" {
+					key = authKey
+					sc.report.consume(TriggerMetadata, authKeyName)
+				}
+			}
+			param, ok := m[key]
+			if ok {
+				sc.report.consume(po, key)
+			}
+			if ok && (param != "" || params.AllowEmpty || sc.AllowEmptyValues) {
+				return name, strings.TrimSpace(param), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// interceptedParamValue is a function that works like configParamValue but passes the found value
+// through the ScalerConfig.ValueInterceptorContext or ValueInterceptor
+func (sc *ScalerConfig) interceptedParamValue(ctx context.Context, params Params) (string, bool, error) {
+	name, val, exists := sc.lookupParamValue(params)
+	if !exists {
+		return val, exists, nil
+	}
+	var err error
+	switch {
+	case sc.ValueInterceptorContext != nil:
+		val, err = sc.ValueInterceptorContext(ctx, name, val)
+	case sc.ValueInterceptor != nil:
+		val, err = sc.ValueInterceptor(name, val)
+	}
+	return val, exists, err
+}
+
+// sourceMap is a function that returns the map of the ScalerConfig the parsing order refers to
+func (sc *ScalerConfig) sourceMap(po ParsingOrder) (map[string]string, bool) {
+	switch po {
+	case TriggerMetadata:
+		return sc.TriggerMetadata, true
+	case AuthParams:
+		return sc.AuthParams, true
+	case ResolvedEnv:
+		return sc.ResolvedEnv, true
+	default:
+		name, isCustom := po.customSourceName()
+		if !isCustom {
+			// this is checked when parsing the tags but adding as default case to avoid any potential future problems
+			return nil, false
+		}
+		return sc.CustomSources[name], true
+	}
+}
+
+// parseFallback is a function that parses the 'fallback' tag value into the chain steps and the literal default,
+// e.g. triggerMetadata:queue;env:QUEUE_NAME;custom:annotations:queue;literal:default
+func parseFallback(val string) ([]FallbackStep, string, bool, error) {
+	var steps []FallbackStep
+	stepsSplit := strings.Split(val, tagValueSeparator)
+	for i, s := range stepsSplit {
+		s = strings.TrimSpace(s)
+		source, key, found := strings.Cut(s, fallbackStepSeparator)
+		if !found {
+			return nil, "", false, fmt.Errorf("malformed fallback step %q, expected <source>%s<key>", s, fallbackStepSeparator)
+		}
+		if source == fallbackLiteralSource {
+			if i != len(stepsSplit)-1 {
+				return nil, "", false, fmt.Errorf("fallback step %q has to be the last one", s)
+			}
+			return steps, key, true, nil
+		}
+		po := ParsingOrder(source)
+		switch {
+		case source == fallbackEnvSource:
+			po = ResolvedEnv
+		case source+fallbackStepSeparator == customParsingOrderPrefix:
+			name, customKey, _ := strings.Cut(key, fallbackStepSeparator)
+			if name == "" {
+				return nil, "", false, fmt.Errorf("malformed fallback step %q, expected %s<name>%s<key>", s, customParsingOrderPrefix, fallbackStepSeparator)
+			}
+			po, key = CustomSource(name), customKey
+		case !allowedParsingOrderMap[po]:
+			return nil, "", false, fmt.Errorf("unknown fallback source %s, has to be one of %v, %s, %s<name> or %s", source, sortedKeys(allowedParsingOrderMap), fallbackEnvSource, customParsingOrderPrefix, fallbackLiteralSource)
+		}
+		if key == "" {
+			return nil, "", false, fmt.Errorf("malformed fallback step %q, missing key", s)
+		}
+		steps = append(steps, FallbackStep{Source: po, Key: key})
+	}
+	return steps, "", false, nil
+}
+
+// parseBoolTag parses a boolean tag, the bare tag name means true
+func parseBoolTag(tsplit []string) (bool, error) {
+	if len(tsplit) == 1 {
+		return true, nil
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(tsplit[1]))
+	if err != nil {
+		return false, fmt.Errorf("invalid %s value %q, has to be a boolean", tsplit[0], tsplit[1])
+	}
+	return b, nil
+}
+
+// paramsFromTag is a function that returns the Params struct based on the field tag
+func paramsFromTag(tag string, field reflect.StructField) (Params, error) {
+	params := Params{FieldName: field.Name}
+	fallbackLiteral, hasFallbackLiteral := "", false
+	var err error
+	tagSplit := splitTag(tag)
+	for _, ts := range tagSplit {
+		tsplit := strings.SplitN(ts, tagKeySeparator, 2)
+		tsplit[0] = strings.TrimSpace(tsplit[0])
+		if len(tsplit) > 1 {
+			tsplit[1] = unquoteTagValue(tsplit[1])
+		}
+		switch tsplit[0] {
+		case optionalTag:
+			if params.Optional, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case orderTag:
+			if len(tsplit) > 1 {
+				order := strings.Split(tsplit[1], tagValueSeparator)
+				for _, po := range order {
+					poTyped := ParsingOrder(strings.TrimSpace(po))
+					if name, isCustom := poTyped.customSourceName(); isCustom && name != "" {
+						params.Order = append(params.Order, poTyped)
+						continue
+					}
+					if !allowedParsingOrderMap[poTyped] {
+						return params, fmt.Errorf("unknown parsing order value %s, has to be one of %v or %s<name>", po, sortedKeys(allowedParsingOrderMap), customParsingOrderPrefix)
+					}
+					params.Order = append(params.Order, poTyped)
+				}
+			}
+		case nameTag:
+			if len(tsplit) > 1 {
+				names := strings.Split(tsplit[1], tagValueSeparator)
+				params.Name = strings.TrimSpace(names[0])
+				for _, n := range names[1:] {
+					params.AltNames = append(params.AltNames, strings.TrimSpace(n))
+				}
+			}
+		case deprecatedTag:
+			if len(tsplit) == 1 {
+				params.Deprecated = deprecatedTag
+			} else {
+				params.Deprecated = strings.TrimSpace(tsplit[1])
+			}
+		case trueTokenTag:
+			if len(tsplit) > 1 {
+				params.TrueToken = strings.TrimSpace(tsplit[1])
+			}
+		case falseTokenTag:
+			if len(tsplit) > 1 {
+				params.FalseToken = strings.TrimSpace(tsplit[1])
+			}
+		case humanIntTag:
+			if params.HumanInt, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case presenceTrueTag:
+			if params.PresenceTrue, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case ratioTag:
+			if params.Ratio, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case fallbackTag:
+			if len(tsplit) > 1 {
+				steps, literal, hasLiteral, err := parseFallback(tsplit[1])
+				if err != nil {
+					return params, fmt.Errorf("field %s: %w", params.FieldName, err)
+				}
+				if len(steps) == 0 {
+					return params, fmt.Errorf("field %s uses '%s' tag without any lookup step", params.FieldName, fallbackTag)
+				}
+				params.Fallback = steps
+				fallbackLiteral, hasFallbackLiteral = literal, hasLiteral
+			}
+		case wholeMetadataTag:
+			if params.WholeMetadata, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case durationUnitTag:
+			if len(tsplit) > 1 {
+				unit, err := time.ParseDuration("1" + strings.TrimSpace(tsplit[1]))
+				if err != nil {
+					return params, fmt.Errorf("invalid durationUnit value %q, has to be one of ns, us, ms, s, m or h", tsplit[1])
+				}
+				params.DurationUnit = unit
+			}
+		case allowHostsTag:
+			params.CheckHosts = true
+			if len(tsplit) > 1 {
+				for _, host := range strings.Split(tsplit[1], tagValueSeparator) {
+					host = strings.TrimSpace(host)
+					if host == "" || (strings.Contains(host, "*") && host != "*" && (!strings.HasPrefix(host, "*.") || strings.Count(host, "*") > 1)) {
+						return params, fmt.Errorf("invalid allowHosts value %q, has to be a host, *.<domain> or *", host)
+					}
+					params.AllowHosts = append(params.AllowHosts, host)
+				}
+			}
+		case decimalCommaTag:
+			if params.DecimalComma, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case renamedFromTag:
+			if len(tsplit) > 1 {
+				params.RenamedFrom = strings.TrimSpace(tsplit[1])
+			}
+		case stripPrefixTag:
+			if len(tsplit) > 1 {
+				params.StripPrefix = tsplit[1]
+			}
+		case gatedTag:
+			if len(tsplit) > 1 {
+				params.Gated = strings.TrimSpace(tsplit[1])
+			}
+		case trimCutsetTag:
+			if len(tsplit) > 1 {
+				params.TrimCutset = tsplit[1]
+			}
+		case csvTag:
+			if params.CSV, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case percentTag:
+			params.Percent = PercentModeFraction
+			if len(tsplit) > 1 {
+				params.Percent = PercentMode(strings.TrimSpace(tsplit[1]))
+				if !allowedPercentModeMap[params.Percent] {
+					return params, fmt.Errorf("unknown percent value %s, has to be one of %v", params.Percent, sortedKeys(allowedPercentModeMap))
+				}
+			}
+		case defaultUnitTag:
+			if len(tsplit) > 1 {
+				unit, err := time.ParseDuration("1" + strings.TrimSpace(tsplit[1]))
+				if err != nil {
+					return params, fmt.Errorf("invalid defaultUnit value %q, has to be one of ns, us, ms, s, m or h", tsplit[1])
+				}
+				params.DefaultUnit = unit
+			}
+		case durationModeTag:
+			if len(tsplit) > 1 {
+				params.DurationMode = DurationMode(strings.TrimSpace(tsplit[1]))
+				if !allowedDurationModeMap[params.DurationMode] {
+					return params, fmt.Errorf("unknown durationMode value %s, has to be one of %v", params.DurationMode, sortedKeys(allowedDurationModeMap))
+				}
+			}
+		case validateTag:
+			if len(tsplit) > 1 {
+				for _, name := range strings.Split(tsplit[1], tagValueSeparator) {
+					name = strings.TrimSpace(name)
+					if _, ok := lookupValidator(name); !ok {
+						return params, fmt.Errorf("unknown validator %q, has to be registered with RegisterValidator", name)
+					}
+					params.Validators = append(params.Validators, name)
+				}
+			}
+		case typeHintsTag:
+			if params.TypeHints, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case multipleOfTag:
+			if len(tsplit) > 1 {
+				multipleOf, err := strconv.ParseInt(strings.TrimSpace(tsplit[1]), 10, 64)
+				if err != nil || multipleOf < 1 {
+					return params, fmt.Errorf("invalid multipleOf value %q, has to be a positive integer", tsplit[1])
+				}
+				params.MultipleOf = multipleOf
+			}
+		case minTag, maxTag:
+			if len(tsplit) > 1 {
+				bound, err := strconv.ParseFloat(strings.TrimSpace(tsplit[1]), 64)
+				if err != nil || math.IsNaN(bound) {
+					return params, fmt.Errorf("invalid %s value %q, has to be a number", tsplit[0], tsplit[1])
+				}
+				if tsplit[0] == minTag {
+					params.Min = &bound
+				} else {
+					params.Max = &bound
+				}
+			}
+		case enumTag:
+			if len(tsplit) > 1 {
+				for _, val := range strings.Split(tsplit[1], tagValueSeparator) {
+					params.Enum = append(params.Enum, strings.TrimSpace(val))
+				}
+			}
+		case schemesTag:
+			if len(tsplit) > 1 {
+				for _, scheme := range strings.Split(tsplit[1], tagValueSeparator) {
+					if scheme = strings.TrimSpace(scheme); scheme != "" {
+						params.Schemes = append(params.Schemes, scheme)
+					}
+				}
+			}
+		case jsonPathTag:
+			if len(tsplit) > 1 {
+				params.JSONPath = strings.TrimSpace(tsplit[1])
+				if _, err := parseJSONPath(params.JSONPath); err != nil {
+					return params, err
+				}
+			}
+		case uniqueTag:
+			if params.Unique, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case ignoreCaseTag:
+			if params.IgnoreCase, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case clampTag:
+			if len(tsplit) > 1 {
+				clamp, err := parseClamp(tsplit[1])
+				if err != nil {
+					return params, err
+				}
+				params.Clamp = clamp
+			}
+		case separatorTag:
+			if len(tsplit) > 1 {
+				params.Separator = tsplit[1]
+			}
+		case keyValSeparatorTag:
+			if len(tsplit) > 1 {
+				params.KeyValSeparator = tsplit[1]
+			}
+		case prefixTag:
+			if len(tsplit) > 1 {
+				params.Prefix = strings.TrimSpace(tsplit[1])
+			}
+		case keyPrefixTag:
+			if len(tsplit) > 1 {
+				params.KeyPrefix = strings.TrimSpace(tsplit[1])
+			}
+		case removedInTag:
+			if len(tsplit) > 1 {
+				params.RemovedIn = strings.TrimSpace(tsplit[1])
+			}
+		case defaultTag:
+			if len(tsplit) > 1 {
+				params.Default = strings.TrimSpace(tsplit[1])
+			}
+		case negateTag:
+			if params.Negate, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case onDuplicateTag:
+			if len(tsplit) > 1 {
+				params.OnDuplicate = OnDuplicate(strings.TrimSpace(tsplit[1]))
+				if !allowedOnDuplicateMap[params.OnDuplicate] {
+					return params, fmt.Errorf("unknown onDuplicate value %s, has to be one of %v", params.OnDuplicate, sortedKeys(allowedOnDuplicateMap))
+				}
+			}
+		case allowEmptyTag:
+			if params.AllowEmpty, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case defaultOnEmptyTag:
+			if params.DefaultOnEmpty, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case rowSeparatorTag:
+			if len(tsplit) > 1 {
+				params.RowSeparator = tsplit[1]
+			}
+		case columnSeparatorTag:
+			if len(tsplit) > 1 {
+				params.ColumnSeparator = tsplit[1]
+			}
+		case rectangularTag:
+			if params.Rectangular, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case maxElemsTag:
+			if len(tsplit) > 1 {
+				maxElems, err := strconv.Atoi(strings.TrimSpace(tsplit[1]))
+				if err != nil || maxElems < 1 {
+					return params, fmt.Errorf("invalid maxElems value %q, has to be a positive integer", tsplit[1])
+				}
+				params.MaxElems = maxElems
+			}
+		case rateTag:
+			if params.Rate, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case lazyTag:
+			if params.Lazy, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case quantityTag:
+			if params.Quantity, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case exclusiveSetTag, requiredIfTag:
+			if len(tsplit) < 2 || strings.TrimSpace(tsplit[1]) == "" {
+				return params, fmt.Errorf("field %s uses '%s' tag without field name", params.FieldName, tsplit[0])
+			}
+			for _, name := range strings.Split(tsplit[1], tagValueSeparator) {
+				name, value, _ := strings.Cut(name, requiredIfValueSeparator)
+				if name = strings.TrimSpace(name); name == "" {
+					return params, fmt.Errorf("field %s uses '%s' tag without field name", params.FieldName, tsplit[0])
+				}
+				if tsplit[0] == exclusiveSetTag {
+					params.ExclusiveSet = append(params.ExclusiveSet, name)
+				} else {
+					params.RequiredIf = append(params.RequiredIf, FieldCondition{Field: name, Value: strings.TrimSpace(value)})
+				}
+			}
+		case lessThanTag, lessThanOrEqualTag, greaterThanTag, greaterThanOrEqualTag:
+			if len(tsplit) < 2 || strings.TrimSpace(tsplit[1]) == "" {
+				return params, fmt.Errorf("field %s uses '%s' tag without field name", params.FieldName, tsplit[0])
+			}
+			params.Comparisons = append(params.Comparisons, FieldComparison{Tag: tsplit[0], Field: strings.TrimSpace(tsplit[1])})
+		case templateTag:
+			if params.Template, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case requiredKeysTag:
+			if len(tsplit) > 1 {
+				for _, k := range strings.Split(tsplit[1], tagValueSeparator) {
+					params.RequiredKeys = append(params.RequiredKeys, strings.TrimSpace(k))
+				}
+			}
+		case docTag:
+			if len(tsplit) > 1 {
+				params.Doc = tsplit[1]
+			}
+		case fromAuthTag:
+			if params.FromAuth, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case sensitiveTag:
+			if params.Sensitive, err = parseBoolTag(tsplit); err != nil {
+				return params, err
+			}
+		case "":
+			continue
+		default:
+			return params, fmt.Errorf("unknown tag param %s: %s", tsplit[0], tag)
+		}
+	}
+	if len(params.Fallback) > 0 && (params.Name != "" || len(params.Order) > 0) {
+		return params, fmt.Errorf("field %s uses '%s' tag together with '%s' or '%s' tag", params.FieldName, fallbackTag, nameTag, orderTag)
+	}
+	if hasFallbackLiteral {
+		if params.Default != "" {
+			return params, fmt.Errorf("field %s uses '%s' tag with %s step together with '%s' tag", params.FieldName, fallbackTag, fallbackLiteralSource, defaultTag)
+		}
+		params.Default = fallbackLiteral
+	}
+	if params.PresenceTrue && params.Default != "" {
+		return params, fmt.Errorf("parameter %q uses '%s' tag together with '%s' tag", params.Name, presenceTrueTag, defaultTag)
+	}
+	if params.DefaultOnEmpty && params.Default == "" {
+		return params, fmt.Errorf("parameter %q uses '%s' tag without '%s' tag", params.Name, defaultOnEmptyTag, defaultTag)
+	}
+	if (params.TrueToken == "") != (params.FalseToken == "") || (params.TrueToken != "" && strings.EqualFold(params.TrueToken, params.FalseToken)) {
+		return params, fmt.Errorf("parameter %q has to use distinct '%s' and '%s' tags together", params.Name, trueTokenTag, falseTokenTag)
+	}
+	if params.WholeMetadata && !params.IsNested() {
+		return params, fmt.Errorf("parameter %q uses '%s' tag together with '%s' tag", params.Name, wholeMetadataTag, nameTag)
+	}
+	if params.DurationMode != "" && params.DurationUnit == 0 {
+		return params, fmt.Errorf("parameter %q uses '%s' tag without '%s' tag", params.Name, durationModeTag, durationUnitTag)
+	}
+	if params.Prefix != "" && !params.IsNested() {
+		return params, fmt.Errorf("parameter %q uses '%s' tag without nested struct, use '%s' tag for single parameters", params.DisplayName(), prefixTag, keyPrefixTag)
+	}
+	if params.KeyPrefix != "" && params.IsNested() {
+		return params, fmt.Errorf("field %s uses '%s' tag without '%s' tag", params.FieldName, keyPrefixTag, nameTag)
+	}
+	if params.RemovedIn != "" && !params.IsDeprecated() {
+		return params, fmt.Errorf("parameter %q uses '%s' tag without '%s' tag", params.Name, removedInTag, deprecatedTag)
+	}
+	if separator, _ := params.separators(); separator == params.keyValSeparator() {
+		return params, fmt.Errorf("parameter %q uses the same value for '%s' and '%s' tags", params.Name, separatorTag, keyValSeparatorTag)
+	}
+	if params.IgnoreCase && len(params.Enum) == 0 {
+		return params, fmt.Errorf("parameter %q uses '%s' tag without '%s' tag", params.Name, ignoreCaseTag, enumTag)
+	}
+	if len(params.RequiredIf) > 0 && !params.Optional {
+		return params, fmt.Errorf("parameter %q uses '%s' tag without '%s' tag", params.Name, requiredIfTag, optionalTag)
+	}
+	if params.Min != nil && params.Max != nil && *params.Min > *params.Max {
+		return params, fmt.Errorf("parameter %q uses '%s' tag greater than '%s' tag", params.Name, minTag, maxTag)
+	}
+	if params.FromAuth {
+		t := field.Type
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.String {
+			return params, fmt.Errorf("parameter %q uses '%s' tag, expected string field, has type %v", params.Name, fromAuthTag, field.Type)
+		}
+	}
+	return params, nil
+}
+
+// splitTag is a function that splits the tag into the tag parameters, separators within
+// a single quoted tag value are preserved, e.g. doc='name of the queue, case sensitive'
+func splitTag(tag string) []string {
+	var tagSplit []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case strings.HasPrefix(tag[i:], tagValueQuote):
+			inQuotes = !inQuotes
+		case !inQuotes && strings.HasPrefix(tag[i:], tagSeparator):
+			tagSplit = append(tagSplit, tag[start:i])
+			start = i + len(tagSeparator)
+		}
+	}
+	return append(tagSplit, tag[start:])
+}
+
+// unquoteTagValue is a function that trims the tag value and removes the enclosing single quotes if present
+func unquoteTagValue(val string) string {
+	val = strings.TrimSpace(val)
+	if len(val) >= 2*len(tagValueQuote) && strings.HasPrefix(val, tagValueQuote) && strings.HasSuffix(val, tagValueQuote) {
+		return val[len(tagValueQuote) : len(val)-len(tagValueQuote)]
+	}
+	return val
+}
+
+// DescribeConfig is a function that returns the parameters declared by the keda tags of the typedConfig
+// without looking up any values, e.g. for generating the scaler reference documentation
+func DescribeConfig(typedConfig any) ([]Params, error) {
+	t := reflect.TypeOf(typedConfig)
+	if t == nil {
+		return nil, fmt.Errorf("typedConfig must be a struct or a pointer to a struct")
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("typedConfig must be a struct or a pointer to a struct")
+	}
+	params, errs := describeConfigType(t, "")
+	return params, errors.Join(errs...)
+}
+
+// describeConfigType is a function that returns the parameters declared by the keda tags of the struct type,
+// the fields of the nested structs are described in place of the nested field with the 'prefix' tag applied
+func describeConfigType(t reflect.Type, keyPrefix string) ([]Params, []error) {
+	params := []Params{}
+	errs := []error{}
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+		tag, exists := fieldType.Tag.Lookup("keda")
+		if !exists || tag == skipTag {
+			continue
+		}
+		tagParams, err := paramsFromTag(tag, fieldType)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		tagParams.KeyPrefix = joinKeyPrefix(keyPrefix, tagParams.KeyPrefix)
+		if !tagParams.IsNested() {
+			params = append(params, tagParams)
+			continue
+		}
+		nestedType := fieldType.Type
+		for nestedType.Kind() == reflect.Pointer {
+			nestedType = nestedType.Elem()
+		}
+		if nestedType.Kind() != reflect.Struct {
+			errs = append(errs, fmt.Errorf("nested parameter %q must be a struct, has kind %q", tagParams.FieldName, nestedType.Kind()))
+			continue
+		}
+		nestedParams, nestedErrs := describeConfigType(nestedType, joinKeyPrefix(keyPrefix, tagParams.Prefix))
+		params = append(params, nestedParams...)
+		errs = append(errs, nestedErrs...)
+	}
+	return params, errs
+}
+
+// sortedKeys is a function that returns the sorted keys of the map, used to produce stable error messages
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
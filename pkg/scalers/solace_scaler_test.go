@@ -18,6 +18,7 @@ type testSolaceMetadata struct {
 	metadata     map[string]string
 	triggerIndex int
 	isError      bool
+	parity       *metadataParity
 }
 
 var (
@@ -52,6 +53,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		"#001 - EMPTY", map[string]string{},
 		1,
 		true,
+		nil,
 	},
 	// +Case - brokerBaseUrl
 	{
@@ -69,6 +71,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	// -Case - missing username (clear)
 	{
@@ -85,6 +88,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	// -Case - missing password (clear)
 	{
@@ -101,6 +105,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	// -Case - missing queue
 	{
@@ -117,6 +122,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	// -Case - missing msgCountTarget
 	{
@@ -134,6 +140,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	// -Case - msgSpoolUsageTarget non-numeric
 	{
@@ -150,6 +157,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	// -Case - msgSpoolUsage non-numeric
 	{
@@ -166,6 +174,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	// -Case - activationMsgSpoolUsageTarget non-numeric
 	{
@@ -183,6 +192,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	// -Case - activationMsgSpoolUsage non-numeric
 	{
@@ -200,6 +210,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	// +Case - Pass with msgSpoolUsageTarget and not msgCountTarget
 	{
@@ -216,6 +227,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	// -Case - msgRxRateTarget non-numeric
 	{
@@ -232,6 +244,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	// -Case - activationMsgRxRateTarget non-numeric
 	{
@@ -249,6 +262,7 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	// +Case - Properly encode queueName
 	{
@@ -266,6 +280,52 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
+	},
+	// -Case - no positive target
+	{
+		"#017 - no positive target",
+		map[string]string{
+			solaceMetaSempBaseURL:     soltestValidBaseURL,
+			solaceMetaMsgVpn:          soltestValidVpn,
+			solaceMetaUsername:        soltestValidUsername,
+			solaceMetaPassword:        soltestValidPassword,
+			solaceMetaQueueName:       soltestValidQueueName,
+			solaceMetaMsgRxRateTarget: "0",
+		},
+		1,
+		true,
+		&metadataParity{err: "no target value found in the scaler configuration, at least one of messageCountTarget, messageSpoolUsageTarget or messageReceiveRateTarget must be set to a positive value"},
+	},
+	// -Case - relative solaceSempBaseURL
+	{
+		"#018 - relative solaceSempBaseURL",
+		map[string]string{
+			solaceMetaSempBaseURL:    "localhost:8080",
+			solaceMetaMsgVpn:         soltestValidVpn,
+			solaceMetaUsername:       soltestValidUsername,
+			solaceMetaPassword:       soltestValidPassword,
+			solaceMetaQueueName:      soltestValidQueueName,
+			solaceMetaMsgCountTarget: soltestValidMsgCountTarget,
+		},
+		1,
+		true,
+		&metadataParity{err: `(param "solaceSempBaseURL")`},
+	},
+	// -Case - messageCountTarget not a number
+	{
+		"#019 - invalid messageCountTarget",
+		map[string]string{
+			solaceMetaSempBaseURL:    soltestValidBaseURL,
+			solaceMetaMsgVpn:         soltestValidVpn,
+			solaceMetaUsername:       soltestValidUsername,
+			solaceMetaPassword:       soltestValidPassword,
+			solaceMetaQueueName:      soltestValidQueueName,
+			solaceMetaMsgCountTarget: "ten",
+		},
+		1,
+		true,
+		&metadataParity{err: `(param "messageCountTarget")`},
 	},
 }
 
@@ -285,6 +345,7 @@ var testSolaceEnvCreds = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	// -Case - Should fail with ENV var not found
 	{
@@ -301,6 +362,29 @@ var testSolaceEnvCreds = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
+	},
+	// +Case - spool usage targets are converted from MB to bytes
+	{
+		"#103 - spool usage targets in bytes",
+		map[string]string{
+			solaceMetaSempBaseURL:                   soltestValidBaseURL,
+			solaceMetaMsgVpn:                        soltestValidVpn,
+			solaceMetaUsernameFromEnv:               soltestEnvUsername,
+			solaceMetaPasswordFromEnv:               soltestEnvPassword,
+			solaceMetaQueueName:                     soltestValidQueueName,
+			solaceMetaMsgSpoolUsageTarget:           soltestValidMsgSpoolTarget,
+			solaceMetaActivationMsgSpoolUsageTarget: "2",
+		},
+		1,
+		false,
+		&metadataParity{fields: map[string]any{
+			"Username":                      soltestValidUsername,
+			"Password":                      soltestValidPassword,
+			"MsgSpoolUsageTarget":           20 * 1024 * 1024,
+			"ActivationMsgSpoolUsageTarget": 2 * 1024 * 1024,
+			"endpointURL":                   "http://localhost:8080/SEMP/v2/monitor/msgVpns/dennis_vpn/queues/queue3" + solaceSempQueryFieldURLSuffix,
+		}},
 	},
 }
 
@@ -322,6 +406,7 @@ var testSolaceK8sSecretCreds = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	// +Case - should find creds
 	{
@@ -338,6 +423,7 @@ var testSolaceK8sSecretCreds = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	// +Case - Should find with creds
 	{
@@ -354,6 +440,7 @@ var testSolaceK8sSecretCreds = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 }
 
@@ -373,6 +460,7 @@ var testSolaceGetMetricSpecData = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	{
 		"#402 - Get Metric Spec - msgSpoolUsageTarget",
@@ -389,6 +477,7 @@ var testSolaceGetMetricSpecData = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	{
 		"#403 - Get Metric Spec - BOTH msgSpoolUsage and msgCountTarget",
@@ -405,6 +494,7 @@ var testSolaceGetMetricSpecData = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	{
 		"#404 - Get Metric Spec - BOTH MISSING",
@@ -421,6 +511,7 @@ var testSolaceGetMetricSpecData = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	{
 		"#405 - Get Metric Spec - BOTH ZERO",
@@ -437,6 +528,7 @@ var testSolaceGetMetricSpecData = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	{
 		"#406 - Get Metric Spec - ONE ZERO; OTHER VALID",
@@ -453,6 +545,7 @@ var testSolaceGetMetricSpecData = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	// Added for 'solaceMetaMsgRxRateTarget'
 	{
@@ -471,6 +564,7 @@ var testSolaceGetMetricSpecData = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	{
 		"#411 - Get Metric Spec - ALL msgSpoolUsage, msgCountTarget, and msgRxRateTarget",
@@ -488,6 +582,7 @@ var testSolaceGetMetricSpecData = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 	{
 		"#412 - Get Metric Spec - ALL ZERO",
@@ -505,6 +600,7 @@ var testSolaceGetMetricSpecData = []testSolaceMetadata{
 		},
 		1,
 		true,
+		nil,
 	},
 	{
 		"#413 - Get Metric Spec - msgRxRateTarget, OTHERS ZERO",
@@ -522,6 +618,7 @@ var testSolaceGetMetricSpecData = []testSolaceMetadata{
 		},
 		1,
 		false,
+		nil,
 	},
 }
 
@@ -545,6 +642,7 @@ func TestSolaceParseSolaceMetadata(t *testing.T) {
 		default:
 			fmt.Println(" --> PASS")
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
 		if !testData.isError && strings.Contains(testData.metadata["queueName"], "/") && !strings.Contains(meta.endpointURL, url.QueryEscape(testData.metadata["queueName"])) {
 			t.Error("expected endpointURL to query escape special characters in the URL but got:", meta.endpointURL)
 			fmt.Println(" --> FAIL")
@@ -552,7 +650,7 @@ func TestSolaceParseSolaceMetadata(t *testing.T) {
 	}
 	for _, testData := range testSolaceEnvCreds {
 		fmt.Print(testData.testID)
-		_, err := parseSolaceMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: testDataSolaceResolvedEnvVALID, TriggerMetadata: testData.metadata, AuthParams: nil, TriggerIndex: testData.triggerIndex})
+		meta, err := parseSolaceMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: testDataSolaceResolvedEnvVALID, TriggerMetadata: testData.metadata, AuthParams: nil, TriggerIndex: testData.triggerIndex})
 		switch {
 		case err != nil && !testData.isError:
 			t.Error("expected success but got error: ", err)
@@ -563,6 +661,7 @@ func TestSolaceParseSolaceMetadata(t *testing.T) {
 		default:
 			fmt.Println(" --> PASS")
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
 	}
 	for _, testData := range testSolaceK8sSecretCreds {
 		fmt.Print(testData.testID)
@@ -3,10 +3,8 @@ package scalers
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
 
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -45,19 +43,20 @@ type stanScaler struct {
 }
 
 type stanMetadata struct {
-	monitoringEndpoint     string
-	stanChannelsEndpoint   string
-	queueGroup             string
-	durableName            string
-	subject                string
-	lagThreshold           int64
-	activationLagThreshold int64
-	triggerIndex           int
+	MonitoringEndpoint     natsMonitoringEndpoint `keda:""`
+	QueueGroup             string                 `keda:"name=queueGroup,             order=triggerMetadata"`
+	DurableName            string                 `keda:"name=durableName,            order=triggerMetadata"`
+	Subject                string                 `keda:"name=subject,                order=triggerMetadata"`
+	LagThreshold           int64                  `keda:"name=lagThreshold,           order=triggerMetadata, default=10, min=1"`
+	ActivationLagThreshold int64                  `keda:"name=activationLagThreshold, order=triggerMetadata, default=0"`
+
+	monitoringEndpoint   string
+	stanChannelsEndpoint string
+	triggerIndex         int
 }
 
 const (
 	stanMetricType             = "External"
-	defaultStanLagThreshold    = 10
 	natsStreamingHTTPProtocol  = "http"
 	natsStreamingHTTPSProtocol = "https"
 )
@@ -85,58 +84,12 @@ func NewStanScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 
 func parseStanMetadata(config *scalersconfig.ScalerConfig) (stanMetadata, error) {
 	meta := stanMetadata{}
-
-	if config.TriggerMetadata["queueGroup"] == "" {
-		return meta, errors.New("no queue group given")
-	}
-	meta.queueGroup = config.TriggerMetadata["queueGroup"]
-
-	if config.TriggerMetadata["durableName"] == "" {
-		return meta, errors.New("no durable name group given")
-	}
-	meta.durableName = config.TriggerMetadata["durableName"]
-
-	if config.TriggerMetadata["subject"] == "" {
-		return meta, errors.New("no subject given")
-	}
-	meta.subject = config.TriggerMetadata["subject"]
-
-	meta.lagThreshold = defaultStanLagThreshold
-
-	if val, ok := config.TriggerMetadata[lagThresholdMetricName]; ok {
-		t, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing %s: %w", lagThresholdMetricName, err)
-		}
-		meta.lagThreshold = t
-	}
-
-	meta.activationLagThreshold = 0
-	if val, ok := config.TriggerMetadata["activationLagThreshold"]; ok {
-		activationTargetQueryValue, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("activationLagThreshold parsing error %w", err)
-		}
-		meta.activationLagThreshold = activationTargetQueryValue
-	}
-
-	meta.triggerIndex = config.TriggerIndex
-
-	var err error
-	useHTTPS := false
-	if val, ok := config.TriggerMetadata["useHttps"]; ok {
-		useHTTPS, err = strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("useHTTPS parsing error %w", err)
-		}
-	}
-	natsServerEndpoint, err := GetFromAuthOrMeta(config, "natsServerMonitoringEndpoint")
-	if err != nil {
+	if err := config.TypedConfig(&meta); err != nil {
 		return meta, err
 	}
-	meta.stanChannelsEndpoint = getSTANChannelsEndpoint(useHTTPS, natsServerEndpoint)
-	meta.monitoringEndpoint = getMonitoringEndpoint(meta.stanChannelsEndpoint, meta.subject)
-
+	meta.triggerIndex = config.TriggerIndex
+	meta.stanChannelsEndpoint = getSTANChannelsEndpoint(meta.MonitoringEndpoint.UseHTTPS, meta.MonitoringEndpoint.NATSServerMonitoringEndpoint)
+	meta.monitoringEndpoint = getMonitoringEndpoint(meta.stanChannelsEndpoint, meta.Subject)
 	return meta, nil
 }
 
@@ -154,7 +107,7 @@ func getMonitoringEndpoint(stanChannelsEndpoint string, subject string) string {
 
 func (s *stanScaler) getMaxMsgLag() int64 {
 	maxValue := int64(0)
-	combinedQueueName := s.metadata.durableName + ":" + s.metadata.queueGroup
+	combinedQueueName := s.metadata.DurableName + ":" + s.metadata.QueueGroup
 
 	for _, subs := range s.channelInfo.Subscriber {
 		if subs.LastSent > maxValue && subs.QueueName == combinedQueueName {
@@ -167,7 +120,7 @@ func (s *stanScaler) getMaxMsgLag() int64 {
 
 func (s *stanScaler) hasPendingMessage() bool {
 	subscriberFound := false
-	combinedQueueName := s.metadata.durableName + ":" + s.metadata.queueGroup
+	combinedQueueName := s.metadata.DurableName + ":" + s.metadata.QueueGroup
 
 	for _, subs := range s.channelInfo.Subscriber {
 		if subs.QueueName == combinedQueueName {
@@ -189,12 +142,12 @@ func (s *stanScaler) hasPendingMessage() bool {
 }
 
 func (s *stanScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	metricName := kedautil.NormalizeString(fmt.Sprintf("stan-%s", s.metadata.subject))
+	metricName := kedautil.NormalizeString(fmt.Sprintf("stan-%s", s.metadata.Subject))
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.lagThreshold),
+		Target: GetMetricTarget(s.metricType, s.metadata.LagThreshold),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: stanMetricType,
@@ -226,7 +179,7 @@ func (s *stanScaler) GetMetricsAndActivity(ctx context.Context, metricName strin
 		}
 		defer baseResp.Body.Close()
 		if baseResp.StatusCode == 404 {
-			s.logger.Info("Streaming broker endpoint returned 404. Please ensure it has been created", "url", s.metadata.monitoringEndpoint, "channelName", s.metadata.subject)
+			s.logger.Info("Streaming broker endpoint returned 404. Please ensure it has been created", "url", s.metadata.monitoringEndpoint, "channelName", s.metadata.Subject)
 		} else {
 			s.logger.Info("Unable to connect to STAN. Please ensure you have configured the ScaledObject with the correct endpoint.", "baseResp.StatusCode", baseResp.StatusCode, "monitoringEndpoint", s.metadata.monitoringEndpoint)
 		}
@@ -240,11 +193,11 @@ func (s *stanScaler) GetMetricsAndActivity(ctx context.Context, metricName strin
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 	totalLag := s.getMaxMsgLag()
-	s.logger.V(1).Info("Stan scaler: Providing metrics based on totalLag, threshold", "totalLag", totalLag, "lagThreshold", s.metadata.lagThreshold)
+	s.logger.V(1).Info("Stan scaler: Providing metrics based on totalLag, threshold", "totalLag", totalLag, "lagThreshold", s.metadata.LagThreshold)
 
 	metric := GenerateMetricInMili(metricName, float64(totalLag))
 
-	return []external_metrics.ExternalMetricValue{metric}, s.hasPendingMessage() || totalLag > s.metadata.activationLagThreshold, nil
+	return []external_metrics.ExternalMetricValue{metric}, s.hasPendingMessage() || totalLag > s.metadata.ActivationLagThreshold, nil
 }
 
 // Nothing to close here.
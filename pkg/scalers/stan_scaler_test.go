@@ -15,6 +15,7 @@ type parseStanMetadataTestData struct {
 	metadata   map[string]string
 	authParams map[string]string
 	isError    bool
+	parity     *metadataParity
 }
 
 type stanMetricIdentifier struct {
@@ -25,23 +26,29 @@ type stanMetricIdentifier struct {
 
 var testStanMetadata = []parseStanMetadataTestData{
 	// nothing passed
-	{map[string]string{}, map[string]string{}, true},
+	{map[string]string{}, map[string]string{}, true, nil},
 	// Missing subject name, should fail
-	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "durableName": "ImDurable"}, map[string]string{}, true},
+	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "durableName": "ImDurable"}, map[string]string{}, true, nil},
 	// Missing durable name, should fail
-	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "subject": "mySubject"}, map[string]string{}, true},
+	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "subject": "mySubject"}, map[string]string{}, true, nil},
 	// Missing nats server monitoring endpoint, should fail
-	{map[string]string{"queueGroup": "grp1", "subject": "mySubject"}, map[string]string{}, true},
+	{map[string]string{"queueGroup": "grp1", "subject": "mySubject"}, map[string]string{}, true, nil},
 	// All good.
-	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject", "useHttps": "true"}, map[string]string{}, false},
+	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject", "useHttps": "true"}, map[string]string{}, false, nil},
 	// All good + activationLagThreshold
-	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject", "activationLagThreshold": "10"}, map[string]string{}, false},
+	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject", "activationLagThreshold": "10"}, map[string]string{}, false, nil},
 	// natsServerMonitoringEndpoint is defined in authParams
-	{map[string]string{"queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject"}, map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss"}, false},
+	{map[string]string{"queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject"}, map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss"}, false, nil},
 	// Missing nats server monitoring endpoint , should fail
-	{map[string]string{"queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject"}, map[string]string{"natsServerMonitoringEndpoint": ""}, true},
+	{map[string]string{"queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject"}, map[string]string{"natsServerMonitoringEndpoint": ""}, true, nil},
 	// Misconfigured https, should fail
-	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject", "useHttps": "error"}, map[string]string{}, true},
+	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject", "useHttps": "error"}, map[string]string{}, true, nil},
+	// lagThreshold default and http endpoints
+	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss:8222", "queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject"}, map[string]string{}, false, &metadataParity{fields: map[string]any{"LagThreshold": 10, "stanChannelsEndpoint": "http://stan-nats-ss:8222/streaming/channelsz", "monitoringEndpoint": "http://stan-nats-ss:8222/streaming/channelsz?channel=mySubject&subs=1"}}},
+	// https endpoints with natsServerMonitoringEndpoint from authParams
+	{map[string]string{"queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject", "useHttps": "true"}, map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss:8222"}, false, &metadataParity{fields: map[string]any{"stanChannelsEndpoint": "https://stan-nats-ss:8222/streaming/channelsz", "monitoringEndpoint": "https://stan-nats-ss:8222/streaming/channelsz?channel=mySubject&subs=1"}}},
+	// all missing parameters are reported
+	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss:8222"}, map[string]string{}, true, &metadataParity{err: "missing required field QueueGroup (param \"queueGroup\") in [triggerMetadata]\nmissing required field DurableName (param \"durableName\") in [triggerMetadata]\nmissing required field Subject (param \"subject\") in [triggerMetadata]"}},
 }
 
 var stanMetricIdentifiers = []stanMetricIdentifier{
@@ -51,12 +58,13 @@ var stanMetricIdentifiers = []stanMetricIdentifier{
 
 func TestStanParseMetadata(t *testing.T) {
 	for _, testData := range testStanMetadata {
-		_, err := parseStanMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		meta, err := parseStanMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
 		if err != nil && !testData.isError {
 			t.Error("Expected success but got error", err)
 		} else if testData.isError && err == nil {
 			t.Error("Expected error but got success")
 		}
+		checkMetadataParity(t, meta, err, testData.parity)
 	}
 }
 